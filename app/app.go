@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/sk25469/jot/config"
 	"github.com/sk25469/jot/database"
 	"github.com/sk25469/jot/service"
+	"github.com/sk25469/jot/styles"
 )
 
 // App holds the application dependencies
@@ -25,6 +27,13 @@ func Initialize() error {
 		return fmt.Errorf("failed to initialize config: %w", err)
 	}
 
+	// Register mode colors from the mode registry
+	for name, mode := range config.AppConfig.Modes {
+		if mode.Color != "" {
+			styles.SetModeColor(name, lipgloss.Color(mode.Color))
+		}
+	}
+
 	// Initialize database
 	dbPath := filepath.Join(config.GetJotDir(), "jot.db")
 	db, err := database.New(database.Config{
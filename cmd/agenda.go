@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Show overdue, today's and upcoming tasks",
+	Long: `Show open tasks with a due date, grouped by day.
+
+Tasks get a due date, priority and assignee from inline markers:
+
+  - [ ] follow up with infra due:2025-11-03 !high @alice
+
+Use --ics to export every open task with a due date as an iCalendar file
+that calendar apps can subscribe to ("-" writes to stdout).`,
+	RunE: runAgendaCommand,
+}
+
+func runAgendaCommand(cmd *cobra.Command, args []string) error {
+	days, _ := cmd.Flags().GetInt("days")
+	icsPath, _ := cmd.Flags().GetString("ics")
+
+	now := time.Now()
+
+	if icsPath != "" {
+		ics, err := app.Instance.NoteService.AgendaICS(now)
+		if err != nil {
+			return err
+		}
+
+		if icsPath == "-" {
+			fmt.Print(ics)
+			return nil
+		}
+
+		if err := os.WriteFile(icsPath, []byte(ics), 0644); err != nil {
+			return fmt.Errorf("failed to write calendar: %w", err)
+		}
+		fmt.Println(styles.SuccessStyle.Render("✓ Calendar written to " + icsPath))
+		return nil
+	}
+
+	if days < 0 {
+		return fmt.Errorf("--days must not be negative")
+	}
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	agenda, err := app.Instance.NoteService.Agenda(now, days)
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		// Days are implied by the tasks' due dates, so scripts get a flat list
+		var tasks []*models.Task
+		for _, day := range agenda {
+			tasks = append(tasks, day.Tasks...)
+		}
+		return writeRecords(os.Stdout, format, tasks, taskColumns)
+	}
+
+	if len(agenda) == 0 {
+		fmt.Println(styles.WarningStyle.Render("Nothing due."))
+		return nil
+	}
+
+	printAgenda(agenda, now)
+	return nil
+}
+
+func printAgenda(agenda []*models.AgendaDay, now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	header := styles.RenderHeader("Agenda")
+	fmt.Println(header)
+
+	overdueShown := false
+	for _, day := range agenda {
+		if day.Day.Before(today) && !overdueShown {
+			fmt.Println()
+			fmt.Println(lipgloss.NewStyle().Foreground(styles.Error).Bold(true).Render("Overdue"))
+			overdueShown = true
+		}
+
+		fmt.Println()
+		fmt.Println(styles.DateStyle.Render(agendaDayLabel(day.Day, today)))
+
+		for _, task := range day.Tasks {
+			fmt.Println(createAgendaEntry(task))
+		}
+	}
+}
+
+func agendaDayLabel(day, today time.Time) string {
+	label := day.Format("Mon 2006-01-02")
+	switch {
+	case day.Equal(today):
+		return "Today · " + label
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return "Tomorrow · " + label
+	}
+	return label
+}
+
+func createAgendaEntry(task *models.Task) string {
+	parts := []string{styles.IDStyle.Render(task.ID), "  ", task.Text}
+	if meta := renderTaskMetadata(task, false); meta != "" {
+		parts = append(parts, "  ", meta)
+	}
+
+	source := lipgloss.NewStyle().Foreground(styles.Subtle).Render(
+		fmt.Sprintf("%s · %s", task.NoteTitle, task.NoteID))
+	parts = append(parts, "  ", source)
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(lipgloss.JoinHorizontal(lipgloss.Left, parts...))
+}
+
+func init() {
+	agendaCmd.Flags().IntP("days", "d", 7, "Number of upcoming days to show")
+	agendaCmd.Flags().String("ics", "", "Export due tasks as an iCalendar file (- for stdout)")
+	addFormatFlag(agendaCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [id, date or title]",
+	Short: "Hide a note from list and search",
+	Long: `Archive a note: it stays on disk and can still be opened by ID or title,
+but 'jot list' and 'jot search' leave it out unless --archived or --all is
+given. The state is kept as 'archived: true' in the note's frontmatter.
+
+Without an argument, or with one that matches several notes, a picker lets
+you choose the note.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setNoteState(args, app.Instance.NoteService.SetArchived, true, "Archived")
+	},
+}
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive <id, date or title>",
+	Short: "Bring an archived note back into list and search",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setNoteState(args, app.Instance.NoteService.SetArchived, false, "Unarchived")
+	},
+}
+
+// setNoteState resolves the note named by args and turns one of its
+// frontmatter flags on or off
+func setNoteState(args []string, set func(*models.Note, bool) (*models.Note, error), on bool, verb string) error {
+	note, err := selectNote(args)
+	if err != nil || note == nil {
+		return err
+	}
+
+	if _, err := set(note, on); err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✓ %s %s (%s)", verb, note.Title, note.ID)))
+	return nil
+}
+
+// addArchiveFlags registers --archived and --all on a command listing notes
+func addArchiveFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("archived", false, "Show only archived notes")
+	cmd.Flags().Bool("all", false, "Show archived notes along with the others")
+}
+
+// archiveFilter returns the archive filter selected by --archived and --all
+func archiveFilter(cmd *cobra.Command) (models.ArchiveFilter, error) {
+	archived, _ := cmd.Flags().GetBool("archived")
+	all, _ := cmd.Flags().GetBool("all")
+	switch {
+	case archived && all:
+		return 0, fmt.Errorf("--archived and --all cannot be used together")
+	case archived:
+		return models.OnlyArchived, nil
+	case all:
+		return models.IncludeArchived, nil
+	}
+	return models.ExcludeArchived, nil
+}
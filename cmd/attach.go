@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach <id> <file>...",
+	Short: "Attach files to a note",
+	Long: `Copy files into ~/.jot/assets under a name derived from their content and
+append Markdown links to them at the end of the note. Images are embedded with
+![...], other files are linked. Attaching the same file twice stores it once.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAttachCommand,
+}
+
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments <id>",
+	Short: "List the files attached to a note",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAttachmentsCommand,
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove attached files no note links to",
+	Long: `Remove the files in ~/.jot/assets that are no longer linked from any note,
+for example after deleting an attachment's link. Use --dry-run to see what
+would be removed.`,
+	Args: cobra.NoArgs,
+	RunE: runGCCommand,
+}
+
+func runAttachCommand(cmd *cobra.Command, args []string) error {
+	note, attachments, err := app.Instance.NoteService.AttachFiles(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✓ Attached %d file(s) to %s", len(args)-1, note.Title)))
+	fmt.Println()
+	printAttachments(attachments)
+	return nil
+}
+
+func runAttachmentsCommand(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	note, attachments, err := app.Instance.NoteService.Attachments(args[0])
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		return writeRecords(os.Stdout, format, attachments, attachmentColumns)
+	}
+
+	fmt.Println(styles.RenderHeader(fmt.Sprintf("Attachments of %s (%d)", note.Title, len(attachments))))
+	fmt.Println()
+	if len(attachments) == 0 {
+		fmt.Println(styles.WarningStyle.Render("No attachments found."))
+		return nil
+	}
+
+	printAttachments(attachments)
+	return nil
+}
+
+func runGCCommand(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	removed, size, err := app.Instance.NoteService.CollectGarbage(dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(removed) == 0 {
+		fmt.Println(styles.SuccessStyle.Render("✓ No unreferenced assets."))
+		return nil
+	}
+
+	for _, asset := range removed {
+		fmt.Println(styles.ContentStyle.Render(asset))
+	}
+	fmt.Println()
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✓ %s %d unreferenced asset(s), %s", verb, len(removed), formatSize(size))))
+	return nil
+}
+
+func printAttachments(attachments []*models.Attachment) {
+	for _, attachment := range attachments {
+		size := formatSize(attachment.Size)
+		if attachment.Missing {
+			size = lipgloss.NewStyle().Foreground(styles.Error).Render("missing")
+		}
+
+		fmt.Println(lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			styles.DateStyle.Render(fmt.Sprintf("L%-4d", attachment.Line)),
+			" ",
+			styles.ContentStyle.Render(attachment.Name),
+			"  ",
+			lipgloss.NewStyle().Foreground(styles.Subtle).Render(size),
+		))
+		fmt.Println(lipgloss.NewStyle().MarginLeft(6).Foreground(styles.Muted).Render(attachment.Path))
+	}
+}
+
+// formatSize renders a byte count with a binary unit, e.g. 1.5 KiB
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	gcCmd.Flags().Bool("dry-run", false, "List unreferenced assets without removing them")
+	addFormatFlag(attachmentsCmd)
+}
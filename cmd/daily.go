@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/service"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var todayCmd = &cobra.Command{
+	Use:   "today [text]",
+	Short: "Open today's daily note",
+	Long: `Open today's daily note, creating it from the daily template the first time.
+With text, append it as a timestamped bullet without opening the editor.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDailyCommand(cmd, time.Now(), args)
+	},
+}
+
+var yesterdayCmd = &cobra.Command{
+	Use:   "yesterday [text]",
+	Short: "Open yesterday's daily note",
+	Long: `Open yesterday's daily note, creating it from the daily template the first time.
+With text, append it as a timestamped bullet without opening the editor.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDailyCommand(cmd, time.Now().AddDate(0, 0, -1), args)
+	},
+}
+
+var dayCmd = &cobra.Command{
+	Use:   "day <YYYY-MM-DD> [text]",
+	Short: "Open the daily note for a date",
+	Long: `Open the daily note for any date, creating it from the daily template the first time.
+With text, append it as a timestamped bullet without opening the editor.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		day, err := service.ParseDay(args[0])
+		if err != nil {
+			return err
+		}
+		return runDailyCommand(cmd, day, args[1:])
+	},
+}
+
+func runDailyCommand(cmd *cobra.Command, day time.Time, args []string) error {
+	noteService := app.Instance.NoteService
+
+	// Navigate to the nearest day with an entry
+	prev, _ := cmd.Flags().GetBool("prev")
+	next, _ := cmd.Flags().GetBool("next")
+	if prev && next {
+		return fmt.Errorf("--prev and --next cannot be used together")
+	}
+	if prev || next {
+		direction := 1
+		if prev {
+			direction = -1
+		}
+		adjacent, err := noteService.AdjacentDailyDay(day, direction)
+		if err != nil {
+			return err
+		}
+		day = adjacent
+	}
+
+	text := strings.Join(args, " ")
+	if strings.TrimSpace(text) == "" {
+		_, err := noteService.OpenDailyNote(day)
+		return err
+	}
+
+	note, err := noteService.AppendToDailyNote(day, text)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("Added to %s (%s)", note.Title, note.ID)))
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{todayCmd, yesterdayCmd, dayCmd} {
+		c.Flags().Bool("prev", false, "Open the nearest earlier day with an entry")
+		c.Flags().Bool("next", false, "Open the nearest later day with an entry")
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:     "delete [id, date or title]",
+	Aliases: []string{"rm"},
+	Short:   "Delete a note",
+	Long: `Delete a note's file and remove it from the index, after asking for
+confirmation unless --yes is given. Links to it from other notes become broken.
+
+Without an argument, or with one that matches several notes, a picker lets
+you choose the note.`,
+	RunE: runDeleteCommand,
+}
+
+func runDeleteCommand(cmd *cobra.Command, args []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	note, err := selectNote(args)
+	if err != nil || note == nil {
+		return err
+	}
+
+	if !yes && !confirm(fmt.Sprintf("Delete %s (%s)?", note.Title, note.ID)) {
+		fmt.Println("Nothing deleted.")
+		return nil
+	}
+
+	if err := app.Instance.NoteService.DeleteNote(note); err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✓ Deleted %s (%s)", note.Title, note.ID)))
+	return nil
+}
+
+func init() {
+	deleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/config"
+)
+
+// expireNotes archives or trashes the notes whose TTL has run out and warns
+// about the ones expiring soon. Failures only warn so they never block the
+// command that is running.
+func expireNotes() {
+	expired, err := app.Instance.NoteService.ExpireNotes()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	verb := "Archived"
+	if config.AppConfig.Expiry.Action == "trash" {
+		verb = "Moved to trash"
+	}
+	for _, note := range expired {
+		fmt.Fprintf(os.Stderr, "%s expired note %s (%s)\n", verb, note.Title, note.ID)
+	}
+
+	expiring, err := app.Instance.NoteService.ExpiringNotes()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	if len(expiring) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: %d note(s) expire soon:\n", len(expiring))
+	now := time.Now()
+	for _, note := range expiring {
+		fmt.Fprintf(os.Stderr, "  %s (%s) in %s\n", note.Title, note.ID, timeLeft(*note.ExpiresAt, now))
+	}
+}
+
+// timeLeft describes how long until t, in the largest whole unit
+func timeLeft(t, now time.Time) string {
+	left := t.Sub(now)
+	switch {
+	case left < time.Hour:
+		return fmt.Sprintf("%dm", max(int(left.Minutes()), 1))
+	case left < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(left.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(left.Hours()/24))
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
+	"github.com/spf13/cobra"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the graph of notes, links and tags",
+	Long: `Export the knowledge graph of notes, their [[links]] and shared tags as
+Graphviz DOT, a Mermaid flowchart or JSON nodes and edges.
+
+Use --from with --depth to show the neighbourhood of a note, following links
+in both directions. Notes without any links are marked as orphans.
+
+  jot graph --tag kafka --format mermaid
+  jot graph --from f4f1c39 --depth 2 | dot -Tsvg > kafka.svg`,
+	RunE: runGraphCommand,
+}
+
+func runGraphCommand(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	mode, _ := cmd.Flags().GetString("mode")
+	from, _ := cmd.Flags().GetString("from")
+	depth, _ := cmd.Flags().GetInt("depth")
+	orphans, _ := cmd.Flags().GetBool("orphans")
+	noTags, _ := cmd.Flags().GetBool("no-tags")
+
+	if cmd.Flags().Changed("depth") && from == "" {
+		return fmt.Errorf("--depth requires --from")
+	}
+
+	graph, err := app.Instance.NoteService.BuildGraph(models.GraphFilter{
+		Tags:        tags,
+		Mode:        mode,
+		From:        from,
+		Depth:       depth,
+		OrphansOnly: orphans,
+		IncludeTags: !noTags,
+	})
+	if err != nil {
+		return err
+	}
+
+	output, err := service.RenderGraph(graph, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+func init() {
+	graphCmd.Flags().StringP("format", "f", "dot", "Output format: "+strings.Join(service.GraphFormats, ", "))
+	graphCmd.Flags().StringSliceP("tag", "t", []string{}, "Only include notes with this tag (repeatable)")
+	graphCmd.Flags().StringP("mode", "m", "", "Only include notes in this mode")
+	graphCmd.Flags().String("from", "", "Start the graph at this note")
+	graphCmd.Flags().IntP("depth", "d", 1, "Link hops from --from to include")
+	graphCmd.Flags().Bool("orphans", false, "Only include notes without any links")
+	graphCmd.Flags().Bool("no-tags", false, "Leave out shared tag nodes")
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var linksCmd = &cobra.Command{
+	Use:   "links [id]",
+	Short: "Show the [[links]] in a note",
+	Long: `Show the wiki-style links a note makes to other notes.
+
+Links are written as [[Note title]], [[f4f1c39]], [[title|alias text]] or
+[[title#heading]] and resolve like 'jot open' does. Use --broken to list links
+across all notes whose target or heading does not resolve.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLinksCommand,
+}
+
+var backlinksCmd = &cobra.Command{
+	Use:   "backlinks <id>",
+	Short: "Show the notes linking to a note",
+	Long: `Show the notes that link to a note with [[...]], followed by unlinked
+mentions of its title that are candidates for a link.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBacklinksCommand,
+}
+
+func runLinksCommand(cmd *cobra.Command, args []string) error {
+	broken, _ := cmd.Flags().GetBool("broken")
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	if broken {
+		links, err := app.Instance.NoteService.BrokenLinks()
+		if err != nil {
+			return err
+		}
+		if format != "text" {
+			return writeRecords(os.Stdout, format, links, linkColumns)
+		}
+		if len(links) == 0 {
+			fmt.Println(styles.SuccessStyle.Render("✓ No broken links."))
+			return nil
+		}
+
+		fmt.Println(styles.RenderHeader(fmt.Sprintf("Broken links (%d)", len(links))))
+		fmt.Println()
+		for _, link := range links {
+			target := renderLinkTarget(link)
+			if link.TargetID != "" {
+				// The note resolves, so it is the #anchor that is missing
+				target += lipgloss.NewStyle().Foreground(styles.Error).Render(" (no such heading)")
+			}
+			fmt.Println(lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				styles.IDStyle.Render(link.SourceID),
+				"  ",
+				styles.ContentStyle.Render(fmt.Sprintf("%s:%d", link.SourceTitle, link.Line)),
+				"  ",
+				target,
+			))
+		}
+		return nil
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("a note ID is required unless --broken is given")
+	}
+
+	note, links, err := app.Instance.NoteService.Links(args[0])
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		return writeRecords(os.Stdout, format, links, linkColumns)
+	}
+
+	fmt.Println(styles.RenderHeader(fmt.Sprintf("Links from %s (%d)", note.Title, len(links))))
+	fmt.Println()
+	if len(links) == 0 {
+		fmt.Println(styles.WarningStyle.Render("No links found."))
+		return nil
+	}
+
+	for _, link := range links {
+		fmt.Println(createLinkEntry(link.Line, renderLinkTarget(link), link.TargetID, link.TargetTitle))
+	}
+	return nil
+}
+
+func runBacklinksCommand(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	note, links, mentions, err := app.Instance.NoteService.Backlinks(args[0])
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		return writeRecords(os.Stdout, format, backlinkRecords(links, mentions), backlinkColumns)
+	}
+
+	fmt.Println(styles.RenderHeader(fmt.Sprintf("Backlinks to %s (%d)", note.Title, len(links))))
+	fmt.Println()
+	if len(links) == 0 {
+		fmt.Println(styles.WarningStyle.Render("No backlinks found."))
+	}
+	for _, link := range links {
+		fmt.Println(createLinkEntry(link.Line, "", link.SourceID, link.SourceTitle))
+	}
+
+	if len(mentions) > 0 {
+		fmt.Println()
+		fmt.Println(styles.StatsLabelStyle.Render(fmt.Sprintf("Unlinked mentions (%d)", len(mentions))))
+		fmt.Println()
+		for _, mention := range mentions {
+			fmt.Println(createMentionEntry(mention))
+		}
+	}
+
+	return nil
+}
+
+// backlinkRecord is a backlink or unlinked mention in machine-readable output
+type backlinkRecord struct {
+	Type      string `json:"type"` // "link" or "mention"
+	NoteID    string `json:"note_id"`
+	NoteTitle string `json:"note_title"`
+	Line      int    `json:"line"`
+	Text      string `json:"text,omitempty"` // The mentioning line, for mentions
+}
+
+var backlinkColumns = []column[*backlinkRecord]{
+	{"type", func(r *backlinkRecord) string { return r.Type }},
+	{"note_id", func(r *backlinkRecord) string { return r.NoteID }},
+	{"note_title", func(r *backlinkRecord) string { return r.NoteTitle }},
+	{"line", func(r *backlinkRecord) string { return strconv.Itoa(r.Line) }},
+	{"text", func(r *backlinkRecord) string { return r.Text }},
+}
+
+func backlinkRecords(links []*models.Link, mentions []*models.Mention) []*backlinkRecord {
+	var records []*backlinkRecord
+	for _, link := range links {
+		records = append(records, &backlinkRecord{Type: "link", NoteID: link.SourceID, NoteTitle: link.SourceTitle, Line: link.Line})
+	}
+	for _, mention := range mentions {
+		records = append(records, &backlinkRecord{Type: "mention", NoteID: mention.NoteID, NoteTitle: mention.NoteTitle, Line: mention.Line, Text: mention.Text})
+	}
+	return records
+}
+
+// renderLinkTarget shows a link as written, marking broken ones
+func renderLinkTarget(link *models.Link) string {
+	text := "[[" + link.Target
+	if link.Anchor != "" {
+		text += "#" + link.Anchor
+	}
+	if link.Alias != "" {
+		text += "|" + link.Alias
+	}
+	text += "]]"
+
+	if link.TargetID == "" {
+		return lipgloss.NewStyle().Foreground(styles.Error).Render(text + " (broken)")
+	}
+	return lipgloss.NewStyle().Foreground(styles.Secondary).Render(text)
+}
+
+func createLinkEntry(line int, target, noteID, noteTitle string) string {
+	parts := []string{styles.DateStyle.Render(fmt.Sprintf("L%-4d", line))}
+	if target != "" {
+		parts = append(parts, " ", target)
+	}
+	if noteID != "" {
+		parts = append(parts, "  → ", styles.IDStyle.Render(noteID), " ", styles.ContentStyle.Render(noteTitle))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Left, parts...)
+}
+
+func createMentionEntry(mention *models.Mention) string {
+	firstLine := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		styles.IDStyle.Render(mention.NoteID),
+		"  ",
+		styles.ContentStyle.Render(fmt.Sprintf("%s:%d", mention.NoteTitle, mention.Line)),
+	)
+
+	text := styles.Truncate(mention.Text, 80)
+	secondLine := lipgloss.NewStyle().
+		MarginLeft(2).
+		Foreground(styles.Subtle).
+		Render(text)
+
+	return lipgloss.JoinVertical(lipgloss.Left, firstLine, secondLine)
+}
+
+func init() {
+	linksCmd.Flags().Bool("broken", false, "List unresolved links across all notes")
+	addFormatFlag(linksCmd)
+	addFormatFlag(backlinksCmd)
+}
@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/config"
 	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
 	"github.com/sk25469/jot/styles"
 	"github.com/spf13/cobra"
 )
@@ -13,25 +17,81 @@ import (
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all notes",
-	Long:  `List all notes with optional filtering by tag and mode.`,
-	RunE:  runListCommand,
+	Long: `List all notes with optional filtering by tag and mode. Pinned notes come
+first; archived notes are left out unless --archived or --all is given.
+
+--view picks the layout: cards (two lines per note, the default), table or
+compact (one line per note without a header). The table and compact views
+show the --columns given, fitted to the terminal width:
+
+  jot list --view table --columns id,updated,title,words
+
+--group-by splits the list into sections by mode, tag, month, week or
+notebook (the folder a note lives in under the notes directory), each with
+its subtotal. A note with several tags is listed under each of them.`,
+	RunE: runListCommand,
 }
 
 func runListCommand(cmd *cobra.Command, args []string) error {
 	tagFilter, _ := cmd.Flags().GetString("tag")
 	modeFilter, _ := cmd.Flags().GetString("mode")
+	archived, err := archiveFilter(cmd)
+	if err != nil {
+		return err
+	}
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	tmpl, err := outputTemplate(cmd)
+	if err != nil {
+		return err
+	}
+	view, _ := cmd.Flags().GetString("view")
+	if err := checkListView(view); err != nil {
+		return err
+	}
+	columnNames, _ := cmd.Flags().GetStringSlice("columns")
+	if len(columnNames) > 0 && view == "cards" {
+		return fmt.Errorf("--columns applies to the table and compact views")
+	}
+	columns, err := parseListColumns(columnNames)
+	if err != nil {
+		return err
+	}
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	if groupBy != "" && (tmpl != nil || format != "text") {
+		return fmt.Errorf("--group-by applies to text output only")
+	}
 
-	notesList, err := app.Instance.NoteService.ListNotes(tagFilter, modeFilter)
+	notesList, err := app.Instance.NoteService.ListNotes(tagFilter, modeFilter, archived)
 	if err != nil {
 		return err
 	}
 
+	if tmpl != nil {
+		return writeTemplate(os.Stdout, tmpl, notesList)
+	}
+	if format != "text" {
+		return writeRecords(os.Stdout, format, notesList, noteColumns)
+	}
+
 	if len(notesList) == 0 {
 		fmt.Println("No notes found.")
 		return nil
 	}
 
-	printNotesList(notesList)
+	if groupBy != "" {
+		groups, err := service.GroupNotes(notesList, groupBy, config.GetNotesDir())
+		if err != nil {
+			return err
+		}
+		printGroupedNotes(groups, notesList, view, columns)
+	} else if view == "cards" {
+		printNotesList(notesList)
+	} else {
+		printNotesTable(notesList, columns, view == "compact")
+	}
 	return nil
 }
 
@@ -60,9 +120,7 @@ func printNotesList(notesList []*models.Note) {
 
 	// Footer with total count
 	fmt.Println()
-	fmt.Println(styles.RenderSeparator())
-	totalText := fmt.Sprintf("Total: %d notes", len(notesList))
-	fmt.Println(styles.StatsLabelStyle.Render(totalText))
+	printListFooter(len(notesList))
 }
 
 func createNoteEntry(note *models.Note) string {
@@ -73,11 +131,7 @@ func createNoteEntry(note *models.Note) string {
 	dateText := styles.DateStyle.Render(note.CreatedAt.Format("2006-01-02"))
 
 	// Format the title
-	title := note.Title
-	if len(title) > 50 {
-		title = title[:47] + "..."
-	}
-	titleText := styles.ContentStyle.Render(title)
+	titleText := titleStyle(note).Render(styles.Truncate(displayTitle(note), 50))
 
 	// Format the mode badge
 	modeText := styles.GetModeStyle(note.Mode).Render(note.Mode)
@@ -115,14 +169,29 @@ func createNoteEntry(note *models.Note) string {
 	)
 }
 
-func formatNoteTitle(title string) string {
-	if len(title) > 30 {
-		return title[:27] + "..."
+// displayTitle is a note's title marked with a pin when it is pinned
+func displayTitle(note *models.Note) string {
+	if note.Pinned {
+		return "📌 " + note.Title
+	}
+	return note.Title
+}
+
+// titleStyle dims the titles of archived notes
+func titleStyle(note *models.Note) lipgloss.Style {
+	if note.Archived {
+		return lipgloss.NewStyle().Foreground(styles.Subtle)
 	}
-	return title
+	return styles.ContentStyle
 }
 
 func init() {
 	listCmd.Flags().StringP("tag", "t", "", "Filter by tag")
 	listCmd.Flags().StringP("mode", "m", "", "Filter by mode")
+	addArchiveFlags(listCmd)
+	addFormatFlag(listCmd)
+	addTemplateFlag(listCmd)
+	listCmd.Flags().String("view", "cards", "Layout: "+strings.Join(listViews, ", "))
+	listCmd.Flags().String("group-by", "", "Group notes by "+strings.Join(service.GroupFields, ", "))
+	listCmd.Flags().StringSlice("columns", nil, "Columns for table and compact views: id, date, updated, mode, title, tags, words")
 }
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/mattn/go-runewidth"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+)
+
+// listViews are the layouts of jot list: two lines per note, an aligned
+// table with a header, or one aligned line per note
+var listViews = []string{"cards", "table", "compact"}
+
+// noteColumn is a column of the table and compact views
+type noteColumn struct {
+	header   string
+	value    func(*models.Note) string
+	style    func(*models.Note) lipgloss.Style
+	minWidth int // Columns with a minimum width shrink to fit the terminal
+}
+
+var listColumns = map[string]noteColumn{
+	"id": {
+		header: "ID",
+		value:  func(n *models.Note) string { return n.ID },
+		style:  func(*models.Note) lipgloss.Style { return styles.IDStyle },
+	},
+	"date": {
+		header: "DATE",
+		value:  func(n *models.Note) string { return n.CreatedAt.Format("2006-01-02") },
+		style:  func(*models.Note) lipgloss.Style { return styles.DateStyle },
+	},
+	"updated": {
+		header: "UPDATED",
+		value:  func(n *models.Note) string { return n.UpdatedAt.Format("2006-01-02") },
+		style:  func(*models.Note) lipgloss.Style { return styles.DateStyle },
+	},
+	"mode": {
+		header: "MODE",
+		value:  func(n *models.Note) string { return n.Mode },
+		style: func(n *models.Note) lipgloss.Style {
+			return lipgloss.NewStyle().Foreground(styles.ModeColor(n.Mode)).Bold(true)
+		},
+	},
+	"title": {
+		header:   "TITLE",
+		value:    displayTitle,
+		style:    titleStyle,
+		minWidth: 12,
+	},
+	"tags": {
+		header:   "TAGS",
+		value:    func(n *models.Note) string { return strings.Join(n.Tags, ", ") },
+		style:    func(*models.Note) lipgloss.Style { return lipgloss.NewStyle().Foreground(styles.Secondary) },
+		minWidth: 8,
+	},
+	"words": {
+		header: "WORDS",
+		value:  func(n *models.Note) string { return strconv.Itoa(n.WordCount) },
+		style:  func(*models.Note) lipgloss.Style { return lipgloss.NewStyle().Foreground(styles.Subtle) },
+	},
+}
+
+var defaultListColumns = []string{"id", "date", "mode", "title", "tags"}
+
+// columnGap separates the columns of the table and compact views
+const columnGap = "  "
+
+// parseListColumns looks up --columns names, in the order given
+func parseListColumns(names []string) ([]noteColumn, error) {
+	if len(names) == 0 {
+		names = defaultListColumns
+	}
+
+	columns := make([]noteColumn, 0, len(names))
+	for _, name := range names {
+		column, ok := listColumns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: id, date, updated, mode, title, tags, words)", name)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// checkListView validates --view
+func checkListView(view string) error {
+	for _, v := range listViews {
+		if v == view {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown view %q (available: %s)", view, strings.Join(listViews, ", "))
+}
+
+// noteTable lays out notes in aligned columns fitted to the terminal
+type noteTable struct {
+	columns []noteColumn
+	widths  []int
+}
+
+// newNoteTable sizes the columns for a set of notes, and for the column
+// headers when they will be printed
+func newNoteTable(notes []*models.Note, columns []noteColumn, withHeader bool) *noteTable {
+	natural := make([]int, len(columns))
+	minimum := make([]int, len(columns))
+	for i, column := range columns {
+		minimum[i] = column.minWidth
+		if withHeader {
+			natural[i] = runewidth.StringWidth(column.header)
+		}
+	}
+	for _, note := range notes {
+		for i, column := range columns {
+			natural[i] = max(natural[i], runewidth.StringWidth(column.value(note)))
+		}
+	}
+
+	return &noteTable{
+		columns: columns,
+		widths:  fitColumns(natural, minimum, runewidth.StringWidth(columnGap), terminalWidth()),
+	}
+}
+
+func (t *noteTable) printHeader() {
+	header := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		header[i] = column.header
+	}
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	fmt.Println(headerStyle.Render(formatRow(header, t.widths)))
+}
+
+func (t *noteTable) printRows(notes []*models.Note) {
+	for _, note := range notes {
+		parts := make([]string, len(t.columns))
+		for i, column := range t.columns {
+			text := fitCell(column.value(note), t.widths[i], i == len(t.columns)-1)
+			parts[i] = column.style(note).Render(text)
+		}
+		fmt.Println(strings.Join(parts, columnGap))
+	}
+}
+
+// printNotesTable prints notes one per line in aligned columns, with a
+// header and footer unless compact
+func printNotesTable(notes []*models.Note, columns []noteColumn, compact bool) {
+	table := newNoteTable(notes, columns, !compact)
+	if !compact {
+		table.printHeader()
+	}
+	table.printRows(notes)
+	if !compact {
+		printListFooter(len(notes))
+	}
+}
+
+// printGroupedNotes prints a section per group with its subtotal, laid out
+// in a view, followed by an overview of the groups
+func printGroupedNotes(groups []*models.NoteGroup, notes []*models.Note, view string, columns []noteColumn) {
+	// Size the columns once so they line up across sections
+	table := newNoteTable(notes, columns, view == "table")
+
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(styles.RenderHeader(fmt.Sprintf("%s (%d)", group.Name, len(group.Notes))))
+
+		switch view {
+		case "cards":
+			for j, note := range group.Notes {
+				if j > 0 {
+					fmt.Println()
+				}
+				fmt.Println(createNoteEntry(note))
+			}
+		case "table":
+			table.printHeader()
+			table.printRows(group.Notes)
+		default:
+			table.printRows(group.Notes)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(renderGroupOverview(groups, len(notes)))
+}
+
+// renderGroupOverview boxes the subtotal of every group and the note total
+func renderGroupOverview(groups []*models.NoteGroup, total int) string {
+	nameWidth := 0
+	for _, group := range groups {
+		nameWidth = max(nameWidth, runewidth.StringWidth(group.Name))
+	}
+
+	lines := make([]string, 0, len(groups)+2)
+	for _, group := range groups {
+		lines = append(lines, fmt.Sprintf("%s  %s",
+			styles.StatsLabelStyle.Render(runewidth.FillRight(styles.Truncate(group.Name, 40), min(nameWidth, 40))),
+			styles.StatsValueStyle.Render(strconv.Itoa(len(group.Notes)))))
+	}
+	lines = append(lines, "", styles.StatsLabelStyle.Render(fmt.Sprintf("Total: %d notes", total)))
+
+	return styles.RenderBox(fmt.Sprintf("Overview (%d groups)", len(groups)), strings.Join(lines, "\n"))
+}
+
+// printListFooter prints the separator and note count below a list
+func printListFooter(count int) {
+	fmt.Println(styles.RenderSeparator())
+	fmt.Println(styles.StatsLabelStyle.Render(fmt.Sprintf("Total: %d notes", count)))
+}
+
+// formatRow lays out plain cells in columns of the given widths
+func formatRow(cells []string, widths []int) string {
+	parts := make([]string, len(cells))
+	for i, cell := range cells {
+		parts[i] = fitCell(cell, widths[i], i == len(cells)-1)
+	}
+	return strings.Join(parts, columnGap)
+}
+
+// fitCell truncates a cell to a display width and pads it to that width,
+// except in the last column where trailing spaces are left out
+func fitCell(text string, width int, last bool) string {
+	if runewidth.StringWidth(text) > width {
+		text = styles.Truncate(text, width)
+	}
+	if last {
+		return text
+	}
+	return runewidth.FillRight(text, width)
+}
+
+// fitColumns shrinks the columns that have a minimum width, widest first,
+// until the row fits in the available width. A width of 0 means unlimited.
+// Rows may still overflow when every column is at its minimum.
+func fitColumns(natural, minimum []int, gap, available int) []int {
+	widths := append([]int(nil), natural...)
+	if available <= 0 {
+		return widths
+	}
+
+	total := gap * max(0, len(widths)-1)
+	for _, w := range widths {
+		total += w
+	}
+
+	for total > available {
+		widest := -1
+		for i, w := range widths {
+			if minimum[i] > 0 && w > minimum[i] && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// terminalWidth returns the width of the terminal stdout is attached to, or
+// $COLUMNS, or 0 when neither is known
+func terminalWidth() int {
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
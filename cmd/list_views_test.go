@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestFitColumns(t *testing.T) {
+	testCases := []struct {
+		name      string
+		natural   []int
+		minimum   []int
+		available int
+		expected  []int
+	}{
+		{"fits already", []int{7, 10, 20}, []int{0, 0, 12}, 80, []int{7, 10, 20}},
+		{"unlimited", []int{7, 10, 200}, []int{0, 0, 12}, 0, []int{7, 10, 200}},
+		{"shrinks the flexible column", []int{7, 10, 40}, []int{0, 0, 12}, 50, []int{7, 10, 29}},
+		{"widest flexible column first", []int{7, 30, 20}, []int{0, 8, 12}, 51, []int{7, 20, 20}},
+		{"stops at the minimums", []int{7, 30, 20}, []int{0, 8, 12}, 10, []int{7, 8, 12}},
+	}
+
+	for _, tc := range testCases {
+		got := fitColumns(tc.natural, tc.minimum, 2, tc.available)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: fitColumns() = %v, expected %v", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func TestFitCell(t *testing.T) {
+	testCases := []struct {
+		text     string
+		width    int
+		last     bool
+		expected string
+	}{
+		{"short", 8, false, "short   "},
+		{"short", 8, true, "short"},
+		{"Fix offset reset", 10, false, "Fix offse…"},
+		{"café résumé notes", 8, false, "café ré…"},
+		{"日本語のノート", 8, false, "日本語… "},
+		{"🚀 launch plan", 6, true, "🚀 la…"},
+	}
+
+	for _, tc := range testCases {
+		got := fitCell(tc.text, tc.width, tc.last)
+		if got != tc.expected {
+			t.Errorf("fitCell(%q, %d) = %q, expected %q", tc.text, tc.width, got, tc.expected)
+		}
+		if !tc.last && runewidth.StringWidth(got) != tc.width {
+			t.Errorf("fitCell(%q, %d) is %d cells wide", tc.text, tc.width, runewidth.StringWidth(got))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var modesCmd = &cobra.Command{
+	Use:   "modes",
+	Short: "List note modes",
+	Long:  `List the modes declared in the mode registry along with how many notes use each one.`,
+	RunE:  runModesCommand,
+}
+
+func runModesCommand(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	modes, err := app.Instance.NoteService.ListModes()
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		return writeRecords(os.Stdout, format, modes, modeColumns)
+	}
+
+	printModesList(modes)
+	return nil
+}
+
+func printModesList(modes []*models.ModeSummary) {
+	header := styles.RenderHeader(fmt.Sprintf("Modes (%d)", len(modes)))
+	fmt.Println(header)
+	fmt.Println()
+
+	for i, mode := range modes {
+		fmt.Println(createModeEntry(mode))
+
+		if i < len(modes)-1 {
+			fmt.Println()
+		}
+	}
+}
+
+func createModeEntry(mode *models.ModeSummary) string {
+	modeText := styles.GetModeStyle(mode.Name).Render(mode.Name)
+	countText := styles.StatsValueStyle.Render(fmt.Sprintf("(%d)", mode.NoteCount))
+
+	firstLine := lipgloss.JoinHorizontal(lipgloss.Left, modeText, "  ", countText)
+	if !mode.Registered {
+		warning := lipgloss.NewStyle().Foreground(styles.Warning).Render("not in registry")
+		firstLine = lipgloss.JoinHorizontal(lipgloss.Left, firstLine, "  ", warning)
+	}
+
+	// Details about per-mode behaviour
+	var details []string
+	if mode.Directory != "" {
+		details = append(details, "directory: "+mode.Directory)
+	}
+	if mode.Editor != "" {
+		details = append(details, "editor: "+mode.Editor)
+	}
+	if len(mode.DefaultTags) > 0 {
+		details = append(details, "default tags: "+styles.RenderTags(mode.DefaultTags))
+	}
+	if len(mode.RequiredFields) > 0 {
+		details = append(details, "required: "+strings.Join(mode.RequiredFields, ", "))
+	}
+	if mode.TTL != "" {
+		details = append(details, "ttl: "+mode.TTL)
+	}
+
+	lines := []string{firstLine}
+	for _, detail := range details {
+		lines = append(lines, lipgloss.NewStyle().
+			MarginLeft(2).
+			Foreground(styles.Subtle).
+			Render(detail))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func init() {
+	addFormatFlag(modesCmd)
+}
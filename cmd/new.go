@@ -4,23 +4,39 @@ import (
 	"strings"
 
 	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
 	"github.com/spf13/cobra"
 )
 
 var newCmd = &cobra.Command{
 	Use:   "new [title]",
 	Short: "Create a new note",
-	Long:  `Create a new markdown note with optional title, tags, and mode.`,
-	Args:  cobra.ArbitraryArgs,
-	RunE:  runNewCommand,
+	Long: `Create a new markdown note with optional title, tags, and mode.
+#tags in the title become tags and an @mode word sets the mode, e.g.
+'jot new "quick thought @journal #idea"'.
+
+--ttl makes a scratch note expire, e.g. --ttl 7d, 12h or 2w; without it the
+mode's ttl applies, and --ttl never keeps the note. Expired notes are archived,
+or moved to the trash with expiry.action: trash, the next time jot runs.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runNewCommand,
 }
 
 func runNewCommand(cmd *cobra.Command, args []string) error {
-	title := getNoteTitleFromArgs(args)
 	tags, _ := cmd.Flags().GetStringSlice("tag")
 	mode, _ := cmd.Flags().GetString("mode")
+	templateName, _ := cmd.Flags().GetString("template")
+	vars, _ := cmd.Flags().GetStringToString("var")
+	ttl, _ := cmd.Flags().GetString("ttl")
 
-	_, err := app.Instance.NoteService.CreateNote(title, tags, mode)
+	_, err := app.Instance.NoteService.CreateNote(models.CreateOptions{
+		Title:    getNoteTitleFromArgs(args),
+		Tags:     tags,
+		Mode:     mode,
+		Template: templateName,
+		Vars:     vars,
+		TTL:      ttl,
+	})
 	return err
 }
 
@@ -34,5 +50,8 @@ func getNoteTitleFromArgs(args []string) string {
 
 func init() {
 	newCmd.Flags().StringSliceP("tag", "t", []string{}, "Tags for the note")
-	newCmd.Flags().StringP("mode", "m", "", "Mode for the note (defaults to config default)")
+	newCmd.Flags().StringP("mode", "m", "", "Mode for the note, from the mode registry (defaults to config default)")
+	newCmd.Flags().String("template", "", "Template from ~/.jot/templates (defaults to the mode's template)")
+	newCmd.Flags().StringToString("var", map[string]string{}, "Template variable as key=value (repeatable)")
+	newCmd.Flags().String("ttl", "", "Expire the note after this long, e.g. 7d (defaults to the mode's ttl)")
 }
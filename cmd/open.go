@@ -1,21 +1,87 @@
 package cmd
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
 	"github.com/spf13/cobra"
 )
 
 var openCmd = &cobra.Command{
-	Use:   "open <id or title>",
-	Short: "Open a note in your editor",
-	Long:  `Open a note by ID or partial title match in your configured editor.`,
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  runOpenCommand,
+	Use:     "open [id, date or title][#heading]",
+	Aliases: []string{"edit"},
+	Short:   "Open a note in your editor",
+	Long: `Open a note by ID, daily note date (YYYY-MM-DD) or partial title match in your configured editor.
+Add #heading to open it at a heading, e.g. 'jot open f4f1c39#rollback'.
+
+Without an argument the last note you opened or showed is reopened. A partial
+title that matches several notes opens the one you use most (see 'jot recent').
+
+With --pick, or when nothing has been opened yet or no note stands out among
+the matches, a picker lets you choose the note: type to fuzzy match titles,
+tags and IDs, move with the arrow keys or Ctrl-N/Ctrl-P, Enter to open and
+Esc to cancel.
+
+If you change the note's title, links to the old title in other notes are
+rewritten after a preview, unless --no-propagate is given.`,
+	RunE: runOpenCommand,
 }
 
 func runOpenCommand(cmd *cobra.Command, args []string) error {
-	identifier := strings.Join(args, " ")
-	return app.Instance.NoteService.OpenNote(identifier)
+	noPropagate, _ := cmd.Flags().GetBool("no-propagate")
+	pick, _ := cmd.Flags().GetBool("pick")
+
+	note, err := openNote(args, pick)
+	if err != nil || note == nil {
+		return err
+	}
+
+	if noPropagate {
+		return nil
+	}
+
+	plan, err := app.Instance.NoteService.PlanTitleChange(note)
+	if err != nil || plan == nil {
+		return err
+	}
+
+	return confirmAndApplyRename(plan, false, "Title changed, links left unchanged.")
+}
+
+// openNote opens the note named by args in the editor. Without args it opens
+// the last note used, or the one picked when pick is set or there is none; a
+// pick also settles args that match several notes. It returns nil when the
+// pick was cancelled.
+func openNote(args []string, pick bool) (*models.Note, error) {
+	var note *models.Note
+	var err error
+
+	if len(args) == 0 {
+		if !pick {
+			note, err = app.Instance.NoteService.LastNote()
+		}
+		if err == nil && note == nil {
+			note, err = selectNote(nil)
+		}
+	} else {
+		note, err = app.Instance.NoteService.OpenNote(strings.Join(args, " "))
+		var ambiguous *service.AmbiguousNoteError
+		if !errors.As(err, &ambiguous) || !isInteractive() {
+			return note, err
+		}
+		note, err = pickNote(ambiguous.Candidates)
+	}
+
+	if err != nil || note == nil {
+		return nil, err
+	}
+	return note, app.Instance.NoteService.OpenNoteAt(note, 0)
+}
+
+func init() {
+	openCmd.Flags().Bool("no-propagate", false, "Don't rewrite links when the title changes")
+	openCmd.Flags().BoolP("pick", "p", false, "Choose the note in a picker instead of reopening the last one")
 }
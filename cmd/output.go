@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+	"github.com/sk25469/jot/models"
+	"github.com/spf13/cobra"
+)
+
+// outputFormats are the values accepted by --format on read commands. text
+// is the styled human output, the others are meant for scripts.
+var outputFormats = []string{"text", "json", "ndjson", "csv", "tsv"}
+
+// column is one field of a record type's csv/tsv layout. Column names match
+// the record's JSON field names so every format describes the same fields.
+type column[T any] struct {
+	name  string
+	value func(T) string
+}
+
+var noteColumns = []column[*models.Note]{
+	{"id", func(n *models.Note) string { return n.ID }},
+	{"title", func(n *models.Note) string { return n.Title }},
+	{"mode", func(n *models.Note) string { return n.Mode }},
+	{"created_at", func(n *models.Note) string { return formatTimestamp(n.CreatedAt) }},
+	{"updated_at", func(n *models.Note) string { return formatTimestamp(n.UpdatedAt) }},
+	{"tags", func(n *models.Note) string { return strings.Join(n.Tags, ";") }},
+	{"word_count", func(n *models.Note) string { return strconv.Itoa(n.WordCount) }},
+	{"file_path", func(n *models.Note) string { return n.FilePath }},
+	{"pinned", func(n *models.Note) string { return strconv.FormatBool(n.Pinned) }},
+	{"archived", func(n *models.Note) string { return strconv.FormatBool(n.Archived) }},
+	{"expires_at", func(n *models.Note) string { return formatOptionalTimestamp(n.ExpiresAt) }},
+}
+
+// showColumns are the note columns followed by the note's body
+var showColumns = append(mapColumns(noteColumns, func(n *models.NoteContent) *models.Note { return &n.Note }),
+	column[*models.NoteContent]{"content", func(n *models.NoteContent) string { return n.Content }},
+)
+
+var searchColumns = []column[*models.SearchResult]{
+	{"id", func(r *models.SearchResult) string { return r.ID }},
+	{"title", func(r *models.SearchResult) string { return r.Title }},
+	{"mode", func(r *models.SearchResult) string { return r.Mode }},
+	{"created_at", func(r *models.SearchResult) string { return formatTimestamp(r.CreatedAt) }},
+	{"tags", func(r *models.SearchResult) string { return strings.Join(r.Tags, ";") }},
+	{"rank", func(r *models.SearchResult) string { return strconv.FormatFloat(r.Rank, 'f', -1, 64) }},
+	{"match_type", func(r *models.SearchResult) string { return r.MatchType }},
+	{"line", func(r *models.SearchResult) string { return formatLine(r.Line) }},
+	{"heading", func(r *models.SearchResult) string { return r.Heading }},
+	{"snippet", func(r *models.SearchResult) string { return r.Snippet }},
+	{"file_path", func(r *models.SearchResult) string { return r.FilePath }},
+	{"pinned", func(r *models.SearchResult) string { return strconv.FormatBool(r.Pinned) }},
+	{"archived", func(r *models.SearchResult) string { return strconv.FormatBool(r.Archived) }},
+	{"expires_at", func(r *models.SearchResult) string { return formatOptionalTimestamp(r.ExpiresAt) }},
+}
+
+var recentColumns = []column[*models.RecentNote]{
+	{"id", func(r *models.RecentNote) string { return r.ID }},
+	{"title", func(r *models.RecentNote) string { return r.Title }},
+	{"mode", func(r *models.RecentNote) string { return r.Mode }},
+	{"last_used", func(r *models.RecentNote) string { return formatTimestamp(r.LastUsed) }},
+	{"uses", func(r *models.RecentNote) string { return strconv.Itoa(r.Uses) }},
+	{"score", func(r *models.RecentNote) string { return strconv.FormatFloat(r.Score, 'f', -1, 64) }},
+	{"file_path", func(r *models.RecentNote) string { return r.FilePath }},
+}
+
+var taskColumns = []column[*models.Task]{
+	{"id", func(t *models.Task) string { return t.ID }},
+	{"note_id", func(t *models.Task) string { return t.NoteID }},
+	{"note_title", func(t *models.Task) string { return t.NoteTitle }},
+	{"line", func(t *models.Task) string { return strconv.Itoa(t.Line) }},
+	{"done", func(t *models.Task) string { return strconv.FormatBool(t.Done) }},
+	{"text", func(t *models.Task) string { return t.Text }},
+	{"due", func(t *models.Task) string {
+		if t.Due == nil {
+			return ""
+		}
+		return t.Due.Format("2006-01-02")
+	}},
+	{"priority", func(t *models.Task) string { return t.Priority }},
+	{"assignee", func(t *models.Task) string { return t.Assignee }},
+}
+
+var linkColumns = []column[*models.Link]{
+	{"source_id", func(l *models.Link) string { return l.SourceID }},
+	{"source_title", func(l *models.Link) string { return l.SourceTitle }},
+	{"line", func(l *models.Link) string { return strconv.Itoa(l.Line) }},
+	{"target", func(l *models.Link) string { return l.Target }},
+	{"anchor", func(l *models.Link) string { return l.Anchor }},
+	{"alias", func(l *models.Link) string { return l.Alias }},
+	{"target_id", func(l *models.Link) string { return l.TargetID }},
+	{"target_title", func(l *models.Link) string { return l.TargetTitle }},
+}
+
+var headingColumns = []column[*models.Heading]{
+	{"note_id", func(h *models.Heading) string { return h.NoteID }},
+	{"line", func(h *models.Heading) string { return strconv.Itoa(h.Line) }},
+	{"level", func(h *models.Heading) string { return strconv.Itoa(h.Level) }},
+	{"text", func(h *models.Heading) string { return h.Text }},
+	{"slug", func(h *models.Heading) string { return h.Slug }},
+}
+
+var attachmentColumns = []column[*models.Attachment]{
+	{"note_id", func(a *models.Attachment) string { return a.NoteID }},
+	{"line", func(a *models.Attachment) string { return strconv.Itoa(a.Line) }},
+	{"name", func(a *models.Attachment) string { return a.Name }},
+	{"asset", func(a *models.Attachment) string { return a.Asset }},
+	{"path", func(a *models.Attachment) string { return a.Path }},
+	{"size", func(a *models.Attachment) string { return strconv.FormatInt(a.Size, 10) }},
+	{"missing", func(a *models.Attachment) string { return strconv.FormatBool(a.Missing) }},
+}
+
+var modeColumns = []column[*models.ModeSummary]{
+	{"name", func(m *models.ModeSummary) string { return m.Name }},
+	{"color", func(m *models.ModeSummary) string { return m.Color }},
+	{"directory", func(m *models.ModeSummary) string { return m.Directory }},
+	{"editor", func(m *models.ModeSummary) string { return m.Editor }},
+	{"default_tags", func(m *models.ModeSummary) string { return strings.Join(m.DefaultTags, ";") }},
+	{"required_fields", func(m *models.ModeSummary) string { return strings.Join(m.RequiredFields, ";") }},
+	{"note_count", func(m *models.ModeSummary) string { return strconv.Itoa(m.NoteCount) }},
+	{"registered", func(m *models.ModeSummary) string { return strconv.FormatBool(m.Registered) }},
+	{"ttl", func(m *models.ModeSummary) string { return m.TTL }},
+}
+
+// mapColumns adapts columns of one record type to another that contains it
+func mapColumns[T, U any](columns []column[T], get func(U) T) []column[U] {
+	mapped := make([]column[U], len(columns))
+	for i, c := range columns {
+		value := c.value
+		mapped[i] = column[U]{c.name, func(u U) string { return value(get(u)) }}
+	}
+	return mapped
+}
+
+// addFormatFlag registers --format on a read command
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().String("format", "text", "Output format: "+strings.Join(outputFormats, ", "))
+}
+
+// outputFormat returns the validated --format of a command
+func outputFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	format = strings.ToLower(format)
+	for _, f := range outputFormats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(outputFormats, ", "))
+}
+
+// writeRecords writes records in a machine-readable format. json and ndjson
+// use the records' JSON tags, csv and tsv the given columns with a header row.
+func writeRecords[T any](w io.Writer, format string, records []T, columns []column[T]) error {
+	switch format {
+	case "json", "ndjson":
+		if records == nil {
+			records = []T{}
+		}
+		return writeJSON(w, format, records)
+	case "csv", "tsv":
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = c.name
+		}
+		rows := make([][]string, len(records))
+		for i, record := range records {
+			rows[i] = make([]string, len(columns))
+			for j, c := range columns {
+				rows[i][j] = c.value(record)
+			}
+		}
+		return writeTable(w, format, header, rows)
+	}
+	return fmt.Errorf("format %q is not a machine-readable format", format)
+}
+
+// writeJSON writes records as an indented JSON array, or for ndjson as one
+// compact object per line
+func writeJSON[T any](w io.Writer, format string, records []T) error {
+	if format != "ndjson" {
+		return writeObject(w, format, records)
+	}
+
+	for _, record := range records {
+		if err := writeObject(w, format, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeObject writes a single value as indented JSON, or compact on one line
+// for ndjson
+func writeObject(w io.Writer, format string, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if format != "ndjson" {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(v)
+}
+
+// writeTable writes a header and rows as RFC 4180 CSV or as TSV. TSV fields
+// are never quoted, so tabs and newlines inside them become spaces.
+func writeTable(w io.Writer, format string, header []string, rows [][]string) error {
+	if format == "csv" {
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	}
+
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	for _, row := range append([][]string{header}, rows...) {
+		fields := make([]string, len(row))
+		for i, field := range row {
+			fields[i] = clean.Replace(field)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatOptionalTimestamp formats a time that may not be set, like a note's
+// expiry
+func formatOptionalTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTimestamp(*t)
+}
+
+func formatLine(line int) string {
+	if line == 0 {
+		return ""
+	}
+	return strconv.Itoa(line)
+}
+
+// configureColor applies --color. With "auto" colours and text attributes
+// are turned off when stdout is not a terminal or NO_COLOR is set, so piped
+// output is plain text; "always" keeps them, e.g. for fzf --ansi.
+func configureColor(mode string) error {
+	switch mode {
+	case "always":
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	case "never":
+		lipgloss.SetColorProfile(termenv.Ascii)
+	case "auto", "":
+		fd := os.Stdout.Fd()
+		if os.Getenv("NO_COLOR") != "" || !(isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)) {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+	default:
+		return fmt.Errorf("unknown --color %q (available: auto, always, never)", mode)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+// outputTemplateFuncs are the helpers available to --template output
+var outputTemplateFuncs = template.FuncMap{
+	"date":     formatDate,
+	"join":     joinList,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"truncate": truncate,
+	"pad":      pad,
+	"color":    colorize,
+	"mode":     func(mode string) string { return styles.GetModeStyle(mode).Render(mode) },
+	"tags":     styles.RenderTags,
+	"base":     filepath.Base,
+	"dir":      filepath.Dir,
+	"rel":      relativeToNotes,
+	"home":     abbreviateHome,
+}
+
+// addTemplateFlag registers --template on a command with templated output
+func addTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template", "", "Go template for each result, or the name of one in output_templates")
+}
+
+// outputTemplate returns the parsed --template of a command, or nil when none
+// was given. A name from the output_templates config wins over template text.
+func outputTemplate(cmd *cobra.Command) (*template.Template, error) {
+	text, _ := cmd.Flags().GetString("template")
+	if text == "" {
+		return nil, nil
+	}
+	if cmd.Flags().Changed("format") {
+		return nil, fmt.Errorf("--template and --format cannot be combined")
+	}
+
+	name := "--template"
+	if named, ok := config.AppConfig.OutputTemplates[strings.ToLower(text)]; ok {
+		name, text = text, named
+	}
+
+	tmpl, err := template.New(name).Funcs(outputTemplateFuncs).Parse(unescapeTemplate(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// writeTemplate renders a template once per record, each on its own line
+func writeTemplate[T any](w io.Writer, tmpl *template.Template, records []T) error {
+	for _, record := range records {
+		if err := tmpl.Execute(w, record); err != nil {
+			return fmt.Errorf("failed to render output template: %w", err)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unescapeTemplate turns \t, \n and \\ typed in a shell or config file into
+// the characters they stand for, so '{{.ID}}\t{{.Title}}' works unquoted
+func unescapeTemplate(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n").Replace(text)
+}
+
+// formatDate formats a time, or a *time.Time that may be nil, with a Go layout
+func formatDate(layout string, value any) string {
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return t.Format(layout)
+	}
+	return fmt.Sprint(value)
+}
+
+// joinList joins a list with a separator and takes its arguments in either
+// order, so both {{join .Tags ","}} and {{.Tags | join ","}} work
+func joinList(a, b any) string {
+	items, sep := a, b
+	if _, ok := a.(string); ok {
+		items, sep = b, a
+	}
+
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice {
+		return fmt.Sprint(items)
+	}
+	parts := make([]string, value.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(value.Index(i).Interface())
+	}
+	return strings.Join(parts, fmt.Sprint(sep))
+}
+
+// truncate shortens text to at most n terminal cells, ending it with "…" when cut
+func truncate(n int, s string) string {
+	if n <= 0 {
+		return s
+	}
+	return styles.Truncate(s, n)
+}
+
+// pad fills text with spaces on the right up to a width in terminal cells
+func pad(width int, s string) string {
+	return runewidth.FillRight(s, width)
+}
+
+// colorize renders text in a palette color ("accent", "error", ...) or a
+// "#RRGGBB" hex color
+func colorize(name, s string) (string, error) {
+	color, ok := styles.NamedColor(name)
+	if !ok {
+		return "", fmt.Errorf("unknown color %q", name)
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(s), nil
+}
+
+// relativeToNotes returns a path relative to the notes directory
+func relativeToNotes(path string) string {
+	if rel, err := filepath.Rel(config.GetNotesDir(), path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// abbreviateHome replaces the home directory at the start of a path with ~
+func abbreviateHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if strings.HasPrefix(path, home+string(filepath.Separator)) {
+		return "~" + path[len(home):]
+	}
+	return path
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+	"github.com/spf13/cobra"
+)
+
+func TestWriteRecords(t *testing.T) {
+	created := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	expires := created.AddDate(0, 1, 0)
+	notes := []*models.Note{
+		{ID: "abc1234", Title: "Fix \"offset\", reset", Mode: "dev", CreatedAt: created, Tags: []string{"kafka", "debugging"}, Pinned: true, ExpiresAt: &expires},
+		{ID: "def5678", Title: "tab\there", Mode: "journal", CreatedAt: created, Archived: true},
+	}
+
+	testCases := []struct {
+		format   string
+		records  []*models.Note
+		expected string
+	}{
+		{"csv", notes, "id,title,mode,created_at,updated_at,tags,word_count,file_path,pinned,archived,expires_at\n" +
+			"abc1234,\"Fix \"\"offset\"\", reset\",dev,2025-03-14T09:30:00Z,,kafka;debugging,0,,true,false,2025-04-14T09:30:00Z\n" +
+			"def5678,tab\there,journal,2025-03-14T09:30:00Z,,,0,,false,true,\n"},
+		{"tsv", notes, "id\ttitle\tmode\tcreated_at\tupdated_at\ttags\tword_count\tfile_path\tpinned\tarchived\texpires_at\n" +
+			"abc1234\tFix \"offset\", reset\tdev\t2025-03-14T09:30:00Z\t\tkafka;debugging\t0\t\ttrue\tfalse\t2025-04-14T09:30:00Z\n" +
+			"def5678\ttab here\tjournal\t2025-03-14T09:30:00Z\t\t\t0\t\tfalse\ttrue\t\n"},
+		{"json", nil, "[]\n"},
+		{"ndjson", nil, ""},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		if err := writeRecords(&buf, tc.format, tc.records, noteColumns); err != nil {
+			t.Errorf("writeRecords(%s) error: %v", tc.format, err)
+			continue
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("writeRecords(%s) =\n%q\nexpected\n%q", tc.format, buf.String(), tc.expected)
+		}
+	}
+}
+
+func TestWriteRecordsNDJSON(t *testing.T) {
+	tasks := []*models.Task{
+		{ID: "t1", NoteID: "n1", Line: 3, Text: "ship <it>"},
+		{ID: "t2", NoteID: "n1", Line: 4, Text: "done", Done: true},
+	}
+
+	var buf bytes.Buffer
+	if err := writeRecords(&buf, "ndjson", tasks, taskColumns); err != nil {
+		t.Fatalf("writeRecords() error: %v", err)
+	}
+
+	expected := `{"id":"t1","note_id":"n1","line":3,"text":"ship <it>","done":false,"note_title":"","note_mode":"","file_path":""}
+{"id":"t2","note_id":"n1","line":4,"text":"done","done":true,"note_title":"","note_mode":"","file_path":""}
+`
+	if buf.String() != expected {
+		t.Errorf("writeRecords(ndjson) =\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestWriteTemplate(t *testing.T) {
+	created := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	notes := []*models.Note{
+		{ID: "abc1234", Title: "Fix offset reset after rebalance", CreatedAt: created, Tags: []string{"kafka", "debugging"}},
+		{ID: "def5678", Title: "Daily", CreatedAt: created},
+	}
+
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{`{{.ID}}\t{{.CreatedAt | date "2006-01-02"}}\t{{join .Tags ","}}`,
+			"abc1234\t2025-03-14\tkafka,debugging\ndef5678\t2025-03-14\t\n"},
+		{`{{pad 8 .ID}}|{{truncate 10 .Title}}|{{.Tags | join " "}}`,
+			"abc1234 |Fix offse…|kafka debugging\ndef5678 |Daily|\n"},
+		{`{{upper .ID}}\\t`, "ABC1234\\t\nDEF5678\\t\n"},
+	}
+
+	for _, tc := range testCases {
+		cmd := &cobra.Command{}
+		addFormatFlag(cmd)
+		addTemplateFlag(cmd)
+		if err := cmd.Flags().Set("template", tc.template); err != nil {
+			t.Fatal(err)
+		}
+
+		tmpl, err := outputTemplate(cmd)
+		if err != nil {
+			t.Errorf("outputTemplate(%q) error: %v", tc.template, err)
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := writeTemplate(&buf, tmpl, notes); err != nil {
+			t.Errorf("writeTemplate(%q) error: %v", tc.template, err)
+			continue
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("writeTemplate(%q) =\n%q\nexpected\n%q", tc.template, buf.String(), tc.expected)
+		}
+	}
+}
+
+func TestOutputTemplateNamed(t *testing.T) {
+	original := config.AppConfig
+	defer func() { config.AppConfig = original }()
+	config.AppConfig.OutputTemplates = map[string]string{"short": `{{.ID}} {{.Title}}`}
+
+	cmd := &cobra.Command{}
+	addFormatFlag(cmd)
+	addTemplateFlag(cmd)
+	cmd.Flags().Set("template", "short")
+
+	tmpl, err := outputTemplate(cmd)
+	if err != nil {
+		t.Fatalf("outputTemplate() error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeTemplate(&buf, tmpl, []*models.Note{{ID: "abc1234", Title: "Daily"}}); err != nil {
+		t.Fatalf("writeTemplate() error: %v", err)
+	}
+	if buf.String() != "abc1234 Daily\n" {
+		t.Errorf("named template rendered %q", buf.String())
+	}
+
+	cmd.Flags().Set("format", "json")
+	if _, err := outputTemplate(cmd); err == nil {
+		t.Error("outputTemplate() expected an error with --format")
+	}
+}
+
+func TestShowColumns(t *testing.T) {
+	record := &models.NoteContent{
+		Note:    models.Note{ID: "abc1234", Title: "Runbook", Mode: "dev", Tags: []string{"ops"}},
+		Content: "## Step\n",
+	}
+
+	var buf bytes.Buffer
+	if err := writeRecords(&buf, "tsv", []*models.NoteContent{record}, showColumns); err != nil {
+		t.Fatalf("writeRecords() error: %v", err)
+	}
+	expected := "id\ttitle\tmode\tcreated_at\tupdated_at\ttags\tword_count\tfile_path\tpinned\tarchived\texpires_at\tcontent\n" +
+		"abc1234\tRunbook\tdev\t\t\tops\t0\t\tfalse\tfalse\t\t## Step \n"
+	if buf.String() != expected {
+		t.Errorf("writeRecords(show) =\n%q\nexpected\n%q", buf.String(), expected)
+	}
+}
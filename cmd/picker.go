@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
+	"github.com/sk25469/jot/tui"
+)
+
+// selectNote resolves the note named by args. With no args, or args that
+// match several notes, it lets the user pick one on a terminal. A nil note
+// means the pick was cancelled.
+func selectNote(args []string) (*models.Note, error) {
+	if len(args) == 0 {
+		if !isInteractive() {
+			return nil, fmt.Errorf("a note ID, date or title is required")
+		}
+		notes, err := app.Instance.NoteService.ListNotes("", "", models.ExcludeArchived)
+		if err != nil {
+			return nil, err
+		}
+		return pickNote(notes)
+	}
+
+	note, err := app.Instance.NoteService.ResolveNote(strings.Join(args, " "))
+	return pickAmbiguous(note, err)
+}
+
+// pickAmbiguous lets the user pick between the candidates of an ambiguous
+// identifier on a terminal, and passes any other result through
+func pickAmbiguous(note *models.Note, err error) (*models.Note, error) {
+	var ambiguous *service.AmbiguousNoteError
+	if errors.As(err, &ambiguous) && isInteractive() {
+		return pickNote(ambiguous.Candidates)
+	}
+	return note, err
+}
+
+// isInteractive reports whether stdin and stdout are both terminals
+func isInteractive() bool {
+	return term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+}
+
+// pickNote runs the picker full-screen over some notes and returns the one
+// chosen, or nil when cancelled
+func pickNote(notes []*models.Note) (*models.Note, error) {
+	if len(notes) == 0 {
+		return nil, fmt.Errorf("no notes found")
+	}
+	return tui.Pick(notes)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/sk25469/jot/app"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin [id, date or title]",
+	Short: "Keep a note at the top of the list",
+	Long: `Pin a note so 'jot list' shows it before the other notes, whatever the
+sort order. The state is kept as 'pinned: true' in the note's frontmatter.
+
+Without an argument, or with one that matches several notes, a picker lets
+you choose the note.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setNoteState(args, app.Instance.NoteService.SetPinned, true, "Pinned")
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <id, date or title>",
+	Short: "Stop keeping a note at the top of the list",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setNoteState(args, app.Instance.NoteService.SetPinned, false, "Unpinned")
+	},
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List the notes you use most",
+	Long: `List the notes opened or shown most recently and most often, ranked by
+frecency: every use counts, and recent uses count more than old ones.
+
+'jot open' without an argument reopens the last of them, and a partial title
+that matches several notes opens the one ranked highest here.`,
+	Args: cobra.NoArgs,
+	RunE: runRecentCommand,
+}
+
+func runRecentCommand(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	recent, err := app.Instance.NoteService.RecentNotes(limit)
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		return writeRecords(os.Stdout, format, recent, recentColumns)
+	}
+
+	if len(recent) == 0 {
+		fmt.Println(styles.WarningStyle.Render("No notes opened yet."))
+		return nil
+	}
+
+	fmt.Println(styles.RenderHeader(fmt.Sprintf("Recent notes (%d)", len(recent))))
+	fmt.Println()
+	now := time.Now()
+	for _, note := range recent {
+		uses := fmt.Sprintf("%d uses", note.Uses)
+		if note.Uses == 1 {
+			uses = "1 use"
+		}
+		fmt.Println(lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			styles.IDStyle.Render(note.ID),
+			"  ",
+			styles.GetModeStyle(note.Mode).Render(note.Mode),
+			"  ",
+			styles.ContentStyle.Render(styles.Truncate(note.Title, 50)),
+			"  ",
+			lipgloss.NewStyle().Foreground(styles.Subtle).Render(fmt.Sprintf("%s · %s", timeAgo(note.LastUsed, now), uses)),
+		))
+	}
+	return nil
+}
+
+// timeAgo describes how long before now t was, in the largest whole unit
+func timeAgo(t, now time.Time) string {
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	case age < 60*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+	return t.Format("2006-01-02")
+}
+
+func init() {
+	recentCmd.Flags().IntP("limit", "n", 10, "Number of notes to show, 0 for all")
+	addFormatFlag(recentCmd)
+}
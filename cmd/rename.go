@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/service"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <id> <new title>",
+	Short: "Rename a note and update links to it",
+	Long: `Change a note's title and rewrite every [[Old title]] link in other notes
+to the new title, keeping any |alias text.
+
+The rewrites are previewed before anything is written. Use --yes to skip the
+confirmation, --dry-run to only preview and --no-propagate to leave links alone.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runRenameCommand,
+}
+
+func runRenameCommand(cmd *cobra.Command, args []string) error {
+	noPropagate, _ := cmd.Flags().GetBool("no-propagate")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	newTitle := strings.Join(args[1:], " ")
+	plan, err := app.Instance.NoteService.PlanRename(args[0], newTitle, !noPropagate)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		printRenamePlan(plan)
+		return nil
+	}
+
+	return confirmAndApplyRename(plan, yes, "Rename cancelled.")
+}
+
+// confirmAndApplyRename previews the link rewrites of a rename and applies
+// them once confirmed, printing cancelled otherwise
+func confirmAndApplyRename(plan *service.RenamePlan, yes bool, cancelled string) error {
+	printRenamePlan(plan)
+
+	if len(plan.Rewrites) > 0 && !yes && !confirm("Rewrite these links?") {
+		fmt.Println(styles.WarningStyle.Render(cancelled))
+		return nil
+	}
+
+	if err := app.Instance.NoteService.ApplyRename(plan); err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(
+		fmt.Sprintf("✓ Renamed to %q, %d links updated", plan.NewTitle, len(plan.Rewrites))))
+	return nil
+}
+
+func printRenamePlan(plan *service.RenamePlan) {
+	fmt.Println(lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		styles.IDStyle.Render(plan.Note.ID),
+		"  ",
+		styles.ContentStyle.Render(fmt.Sprintf("%q → %q", plan.OldTitle, plan.NewTitle)),
+	))
+
+	if len(plan.Rewrites) == 0 {
+		fmt.Println(lipgloss.NewStyle().Foreground(styles.Subtle).Render("No links to update."))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(styles.StatsLabelStyle.Render(
+		fmt.Sprintf("Links to update (%d in %d notes)", len(plan.Rewrites), rewrittenNotes(plan))))
+
+	removed := lipgloss.NewStyle().Foreground(styles.Error)
+	added := lipgloss.NewStyle().Foreground(styles.Success)
+	for _, rewrite := range plan.Rewrites {
+		fmt.Println()
+		fmt.Println(lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			styles.IDStyle.Render(rewrite.NoteID),
+			"  ",
+			styles.ContentStyle.Render(fmt.Sprintf("%s:%d", rewrite.NoteTitle, rewrite.Line)),
+		))
+		fmt.Println(removed.Render("  - " + rewrite.Before))
+		fmt.Println(added.Render("  + " + rewrite.After))
+	}
+	fmt.Println()
+}
+
+// rewrittenNotes counts the notes whose links a rename rewrites
+func rewrittenNotes(plan *service.RenamePlan) int {
+	notes := make(map[string]bool)
+	for _, rewrite := range plan.Rewrites {
+		notes[rewrite.NoteID] = true
+	}
+	return len(notes)
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no
+func confirm(question string) bool {
+	fmt.Print(question + " [y/N] ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		fmt.Println()
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func init() {
+	renameCmd.Flags().Bool("no-propagate", false, "Only rename the note, leave links unchanged")
+	renameCmd.Flags().Bool("dry-run", false, "Preview the changes without writing anything")
+	renameCmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/service"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Generate a weekly or monthly review note",
+	Long: `Generate a review note listing every note created or updated in the period,
+grouped by mode and tag, with their open tasks and note statistics.
+
+The note is rendered from the review template (~/.jot/templates/review.md) if it
+exists and opened in your editor for annotation.`,
+	Args: cobra.NoArgs,
+	RunE: runReviewCommand,
+}
+
+func runReviewCommand(cmd *cobra.Command, args []string) error {
+	month, _ := cmd.Flags().GetBool("month")
+	sinceStr, _ := cmd.Flags().GetString("since")
+
+	now := time.Now()
+	period := service.WeekPeriod(now)
+	switch {
+	case sinceStr != "":
+		since, err := service.ParseDay(sinceStr)
+		if err != nil {
+			return err
+		}
+		period = service.SincePeriod(since, now)
+	case month:
+		period = service.MonthPeriod(now)
+	}
+
+	note, err := app.Instance.NoteService.CreateReview(period)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("Created %s (%s)", note.Title, note.ID)))
+	return nil
+}
+
+func init() {
+	reviewCmd.Flags().Bool("week", false, "Review the current week (default)")
+	reviewCmd.Flags().Bool("month", false, "Review the current month")
+	reviewCmd.Flags().String("since", "", "Review everything since a date (YYYY-MM-DD)")
+	reviewCmd.MarkFlagsMutuallyExclusive("week", "month", "since")
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/config"
 	"github.com/sk25469/jot/styles"
 	"github.com/spf13/cobra"
 )
@@ -18,7 +19,16 @@ without ever leaving the terminal.
 
 All notes are stored as plain markdown files in ~/.jot/notes/`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return app.Initialize()
+		color, _ := cmd.Flags().GetString("color")
+		if err := configureColor(color); err != nil {
+			return err
+		}
+		config.EditorOverride, _ = cmd.Flags().GetString("editor")
+		if err := app.Initialize(); err != nil {
+			return err
+		}
+		expireNotes()
+		return nil
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
 		return app.Cleanup()
@@ -35,9 +45,37 @@ func Execute() error {
 }
 
 func init() {
+	rootCmd.PersistentFlags().String("editor", "", `Editor command for this run, e.g. "code --wait"`)
+	rootCmd.PersistentFlags().String("color", "auto", "Colour output: auto, always or never")
+
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(openCmd)
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(modesCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(todayCmd)
+	rootCmd.AddCommand(yesterdayCmd)
+	rootCmd.AddCommand(dayCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(tasksCmd)
+	rootCmd.AddCommand(doneCmd)
+	rootCmd.AddCommand(agendaCmd)
+	rootCmd.AddCommand(linksCmd)
+	rootCmd.AddCommand(backlinksCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(tocCmd)
+	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(attachmentsCmd)
+	rootCmd.AddCommand(gcCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(tuiCmd)
+	rootCmd.AddCommand(recentCmd)
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(unpinCmd)
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -14,25 +15,56 @@ import (
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search notes",
-	Long:  `Search notes by title, content, or tags using fuzzy matching.`,
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  runSearchCommand,
+	Long: `Search notes by title, content, or tags using fuzzy matching.
+
+Use --open N to open the Nth result in your editor at its first match.
+Archived notes are only searched with --archived or --all.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearchCommand,
 }
 
 func runSearchCommand(cmd *cobra.Command, args []string) error {
 	query := strings.Join(args, " ")
+	open, _ := cmd.Flags().GetInt("open")
+	archived, err := archiveFilter(cmd)
+	if err != nil {
+		return err
+	}
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	tmpl, err := outputTemplate(cmd)
+	if err != nil {
+		return err
+	}
 
-	results, err := app.Instance.NoteService.SearchNotes(query)
+	results, err := app.Instance.NoteService.SearchNotes(query, archived)
 	if err != nil {
 		return err
 	}
 
+	if tmpl != nil && open == 0 {
+		return writeTemplate(os.Stdout, tmpl, results)
+	}
+	if format != "text" && open == 0 {
+		return writeRecords(os.Stdout, format, results, searchColumns)
+	}
+
 	if len(results) == 0 {
 		noResultsMsg := fmt.Sprintf("No notes found matching: %s", query)
 		fmt.Println(styles.WarningStyle.Render(noResultsMsg))
 		return nil
 	}
 
+	if open > 0 {
+		if open > len(results) {
+			return fmt.Errorf("--open %d: only %d results", open, len(results))
+		}
+		result := results[open-1]
+		return app.Instance.NoteService.OpenNoteAt(&result.Note, result.Line)
+	}
+
 	printSearchResults(results, query)
 	return nil
 }
@@ -128,10 +160,7 @@ func createSearchResultEntry(result *models.SearchResult, index int) string {
 	// Third line: snippet (if available)
 	thirdLine := ""
 	if result.Snippet != "" && result.Snippet != result.Title {
-		snippetText := result.Snippet
-		if len(snippetText) > 100 {
-			snippetText = snippetText[:97] + "..."
-		}
+		snippetText := styles.Truncate(result.Snippet, 100)
 		thirdLine = lipgloss.NewStyle().
 			MarginLeft(4).
 			Foreground(styles.Muted).
@@ -139,14 +168,37 @@ func createSearchResultEntry(result *models.SearchResult, index int) string {
 			Render("\"" + snippetText + "\"")
 	}
 
+	// Location of the first match, with its enclosing heading
+	locationLine := ""
+	if result.Line > 0 {
+		location := fmt.Sprintf("line %d", result.Line)
+		if result.Heading != "" {
+			location = fmt.Sprintf("§ %s · %s", result.Heading, location)
+		}
+		locationLine = lipgloss.NewStyle().
+			MarginLeft(4).
+			Foreground(styles.Subtle).
+			Render(location)
+	}
+
 	// Combine all lines
 	lines := []string{firstLine}
 	if secondLine != "" {
 		lines = append(lines, secondLine)
 	}
+	if locationLine != "" {
+		lines = append(lines, locationLine)
+	}
 	if thirdLine != "" {
 		lines = append(lines, thirdLine)
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
+
+func init() {
+	searchCmd.Flags().Int("open", 0, "Open the Nth result at its first match")
+	addArchiveFlags(searchCmd)
+	addFormatFlag(searchCmd)
+	addTemplateFlag(searchCmd)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/kballard/go-shellquote"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show [id, date or title]",
+	Short: "Render a note in the terminal",
+	Long: `Render a note's Markdown in the terminal: headings, emphasis, lists and
+tasks, quotes, tables, links and code blocks with syntax colouring, below a
+box with the note's frontmatter.
+
+Output longer than the screen goes through $PAGER (less -R by default)
+unless --no-pager is given. --raw prints the file exactly as it is on disk,
+and --format prints the note's fields and body for scripts.
+
+Without an argument, or with one that matches several notes, a picker lets
+you choose the note.`,
+	RunE: runShowCommand,
+}
+
+// frontmatterHeaderFields are shown in the header box's first lines rather
+// than in its list of other fields
+var frontmatterHeaderFields = map[string]bool{
+	"id": true, "title": true, "mode": true, "tags": true, "created": true, "updated": true,
+}
+
+func runShowCommand(cmd *cobra.Command, args []string) error {
+	raw, _ := cmd.Flags().GetBool("raw")
+	noPager, _ := cmd.Flags().GetBool("no-pager")
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if raw && format != "text" {
+		return fmt.Errorf("--raw applies to text output only")
+	}
+
+	note, err := selectNote(args)
+	if err != nil || note == nil {
+		return err
+	}
+
+	note, content, err := app.Instance.NoteService.ReadNote(note.ID)
+	if err != nil {
+		return err
+	}
+
+	if raw {
+		_, err := os.Stdout.WriteString(content)
+		return err
+	}
+
+	fields, body := service.SplitNote(content)
+	if format != "text" {
+		record := &models.NoteContent{Note: *note, Fields: fields, Content: body}
+		if format == "json" || format == "ndjson" {
+			return writeObject(os.Stdout, format, record)
+		}
+		return writeRecords(os.Stdout, format, []*models.NoteContent{record}, showColumns)
+	}
+
+	width := terminalWidth()
+	if width == 0 {
+		width = 80
+	}
+
+	output := renderNoteHeader(note, fields) + "\n\n" + styles.RenderMarkdown(body, width) + "\n"
+
+	if !noPager && needsPager(output) {
+		if err := page(output); err == nil {
+			return nil
+		}
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// renderNoteHeader boxes a note's title, ID, mode, dates and tags, followed
+// by any other frontmatter fields
+func renderNoteHeader(note *models.Note, fields map[string]string) string {
+	subtle := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	lines := []string{lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		styles.IDStyle.Render(note.ID),
+		"  ",
+		styles.GetModeStyle(note.Mode).Render(note.Mode),
+		"  ",
+		styles.DateStyle.Render(note.CreatedAt.Format("2006-01-02 15:04")),
+	)}
+	if !note.UpdatedAt.Equal(note.CreatedAt) {
+		lines = append(lines, subtle.Render("updated "+note.UpdatedAt.Format("2006-01-02 15:04")))
+	}
+	if len(note.Tags) > 0 {
+		lines = append(lines, "tags: "+styles.RenderTags(note.Tags))
+	}
+
+	var keys []string
+	for key := range fields {
+		if !frontmatterHeaderFields[key] && fields[key] != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, styles.StatsLabelStyle.Render(key+": ")+styles.StatsValueStyle.Render(fields[key]))
+	}
+
+	return styles.RenderBox(note.Title, strings.Join(lines, "\n"))
+}
+
+// needsPager reports whether output on a terminal is taller than the screen
+func needsPager(output string) bool {
+	_, height, err := term.GetSize(os.Stdout.Fd())
+	if err != nil || height <= 0 {
+		return false
+	}
+	return strings.Count(output, "\n") > height
+}
+
+// page shows output through $PAGER, or less -R when it is not set. It only
+// fails when the pager can't be started, so the caller can print instead.
+func page(output string) error {
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = "less -R"
+	}
+
+	parts, err := shellquote.Split(pager)
+	if err != nil || len(parts) == 0 {
+		return fmt.Errorf("invalid $PAGER %q", pager)
+	}
+
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Stdin = strings.NewReader(output)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	_ = cmd.Wait()
+	return nil
+}
+
+func init() {
+	showCmd.Flags().Bool("raw", false, "Print the note file verbatim")
+	showCmd.Flags().Bool("no-pager", false, "Don't page output longer than the screen")
+	addFormatFlag(showCmd)
+}
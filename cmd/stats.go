@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sk25469/jot/app"
@@ -19,13 +21,52 @@ var statsCmd = &cobra.Command{
 }
 
 func runStatsCommand(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
 	stats, err := app.Instance.NoteService.GetStats()
 	if err != nil {
 		return err
 	}
 
-	printNoteStatistics(stats)
-	return nil
+	switch format {
+	case "text":
+		printNoteStatistics(stats)
+		return nil
+	case "json", "ndjson":
+		return writeObject(os.Stdout, format, stats)
+	default:
+		return writeTable(os.Stdout, format, []string{"metric", "name", "value"}, statsRows(stats))
+	}
+}
+
+// statsRows flattens statistics into metric, name, value rows: the totals
+// first, then one row per tag and per mode, sorted by name
+func statsRows(stats *models.StatsResult) [][]string {
+	rows := [][]string{
+		{"total_notes", "", strconv.Itoa(stats.TotalNotes)},
+		{"notes_this_week", "", strconv.Itoa(stats.NotesThisWeek)},
+		{"created_today", "", strconv.Itoa(stats.CreatedToday)},
+		{"word_count", "", strconv.Itoa(stats.WordCount)},
+	}
+
+	for _, counts := range []struct {
+		metric string
+		values map[string]int
+	}{{"tag_count", stats.TagCounts}, {"mode_count", stats.ModeStats}} {
+		names := make([]string, 0, len(counts.values))
+		for name := range counts.values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rows = append(rows, []string{counts.metric, name, strconv.Itoa(counts.values[name])})
+		}
+	}
+
+	return rows
 }
 
 func printNoteStatistics(stats *models.StatsResult) {
@@ -172,3 +213,7 @@ func createModesSection(modeStats map[string]int) string {
 	modesContent := lipgloss.JoinVertical(lipgloss.Left, modeEntries...)
 	return styles.RenderBox("📝 Note Modes", modesContent)
 }
+
+func init() {
+	addFormatFlag(statsCmd)
+}
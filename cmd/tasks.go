@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "List open tasks across notes",
+	Long: `List the Markdown checkbox items (- [ ] ...) found in your notes.
+
+Only open tasks are shown unless --all is given. Use the task ID with
+'jot done' to tick a task off, or with --open to edit the note at the task.`,
+	RunE: runTasksCommand,
+}
+
+var doneCmd = &cobra.Command{
+	Use:   "done <task-id>",
+	Short: "Mark a task as done",
+	Long:  `Tick a task's checkbox in its note file. A unique prefix of the task ID is enough.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDoneCommand,
+}
+
+func runTasksCommand(cmd *cobra.Command, args []string) error {
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	mode, _ := cmd.Flags().GetString("mode")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	all, _ := cmd.Flags().GetBool("all")
+	open, _ := cmd.Flags().GetString("open")
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	if open != "" {
+		_, err := app.Instance.NoteService.OpenTask(open)
+		return err
+	}
+
+	tasks, err := app.Instance.NoteService.ListTasks(models.TaskFilter{
+		Tags:        tags,
+		Mode:        mode,
+		Assignee:    assignee,
+		IncludeDone: all,
+	})
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		return writeRecords(os.Stdout, format, tasks, taskColumns)
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println(styles.WarningStyle.Render("No tasks found."))
+		return nil
+	}
+
+	printTasksList(tasks)
+	return nil
+}
+
+func runDoneCommand(cmd *cobra.Command, args []string) error {
+	task, err := app.Instance.NoteService.CompleteTask(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render("✓ Done: " + task.Text))
+	fmt.Printf("  %s %s:%d\n",
+		styles.IDStyle.Render(task.NoteID),
+		task.NoteTitle,
+		task.Line)
+	return nil
+}
+
+func printTasksList(tasks []*models.Task) {
+	header := styles.RenderHeader(fmt.Sprintf("Tasks (%d)", len(tasks)))
+	fmt.Println(header)
+
+	// Tasks are ordered by note, so group them under a heading per note
+	currentNote := ""
+	for _, task := range tasks {
+		if task.NoteID != currentNote {
+			currentNote = task.NoteID
+			fmt.Println()
+			fmt.Println(lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				styles.IDStyle.Render(task.NoteID),
+				"  ",
+				styles.GetModeStyle(task.NoteMode).Render(task.NoteMode),
+				"  ",
+				styles.ContentStyle.Render(task.NoteTitle),
+			))
+		}
+
+		fmt.Println(createTaskEntry(task))
+	}
+}
+
+func createTaskEntry(task *models.Task) string {
+	box := "[ ]"
+	text := task.Text
+	if task.Done {
+		box = "[x]"
+		text = lipgloss.NewStyle().Foreground(styles.Subtle).Strikethrough(true).Render(text)
+	}
+
+	parts := []string{styles.IDStyle.Render(task.ID), "  ", box, " ", text}
+	if meta := renderTaskMetadata(task, true); meta != "" {
+		parts = append(parts, "  ", meta)
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(lipgloss.JoinHorizontal(lipgloss.Left, parts...))
+}
+
+// renderTaskMetadata renders the priority, assignee and optionally the due date of a task
+func renderTaskMetadata(task *models.Task, withDue bool) string {
+	var parts []string
+	if withDue && task.Due != nil {
+		parts = append(parts, styles.DateStyle.Render("due "+task.Due.Format("2006-01-02")))
+	}
+	if task.Priority != "" {
+		style := lipgloss.NewStyle().Foreground(styles.Subtle)
+		if task.Priority == "high" {
+			style = lipgloss.NewStyle().Foreground(styles.Warning).Bold(true)
+		}
+		parts = append(parts, style.Render("!"+task.Priority))
+	}
+	if task.Assignee != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.Secondary).Render("@"+task.Assignee))
+	}
+	return strings.Join(parts, " ")
+}
+
+func init() {
+	tasksCmd.Flags().StringSliceP("tag", "t", []string{}, "Filter by note tag (repeatable)")
+	tasksCmd.Flags().StringP("mode", "m", "", "Filter by note mode")
+	tasksCmd.Flags().String("assignee", "", "Filter by assignee (@name)")
+	tasksCmd.Flags().BoolP("all", "a", false, "Include completed tasks")
+	tasksCmd.Flags().String("open", "", "Open the note at this task in your editor")
+	addFormatFlag(tasksCmd)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage note templates",
+	Long: `Manage note templates stored in ~/.jot/templates.
+
+Templates are Markdown files rendered with Go text/template. Available variables:
+  {{.Title}} {{.Mode}} {{.Tags}} {{.Date}} {{.User}} {{.Vars.key}}
+Helper functions: date, join, upper, lower, now.`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available templates",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplateListCommand,
+}
+
+var templateNewCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Create a new template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateNewCommand,
+}
+
+var templateEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edit an existing template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateEditCommand,
+}
+
+func runTemplateListCommand(cmd *cobra.Command, args []string) error {
+	templates, err := app.Instance.NoteService.ListTemplates()
+	if err != nil {
+		return err
+	}
+
+	if len(templates) == 0 {
+		fmt.Println(styles.WarningStyle.Render("No templates found. Create one with: jot template new <name>"))
+		return nil
+	}
+
+	fmt.Println(styles.RenderHeader(fmt.Sprintf("Templates (%d)", len(templates))))
+	fmt.Println()
+
+	for _, tmpl := range templates {
+		fmt.Println(lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			styles.IDStyle.Render(tmpl.Name),
+			"  ",
+			styles.DateStyle.Render(tmpl.UpdatedAt.Format("2006-01-02")),
+			"  ",
+			lipgloss.NewStyle().Foreground(styles.Subtle).Render(tmpl.Path),
+		))
+	}
+
+	return nil
+}
+
+func runTemplateNewCommand(cmd *cobra.Command, args []string) error {
+	tmpl, err := app.Instance.NoteService.CreateTemplate(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render("Created template " + tmpl.Name))
+	return nil
+}
+
+func runTemplateEditCommand(cmd *cobra.Command, args []string) error {
+	return app.Instance.NoteService.EditTemplate(args[0])
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateNewCmd)
+	templateCmd.AddCommand(templateEditCmd)
+}
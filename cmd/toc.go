@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/styles"
+	"github.com/spf13/cobra"
+)
+
+var tocCmd = &cobra.Command{
+	Use:   "toc <id>",
+	Short: "Show the outline of a note",
+	Long: `Show a note's headings as an outline. Each heading's anchor can be used
+with 'jot open <id>#anchor' and in [[note#anchor]] links.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTocCommand,
+}
+
+func runTocCommand(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	note, headings, err := app.Instance.NoteService.TableOfContents(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	if format != "text" {
+		return writeRecords(os.Stdout, format, headings, headingColumns)
+	}
+
+	fmt.Println(styles.RenderHeader(note.Title))
+	fmt.Println()
+
+	if len(headings) == 0 {
+		fmt.Println(styles.WarningStyle.Render("No headings found."))
+		return nil
+	}
+
+	printOutline(headings)
+	return nil
+}
+
+func printOutline(headings []*models.Heading) {
+	// Indent relative to the shallowest heading in the note
+	minLevel := headings[0].Level
+	for _, heading := range headings {
+		if heading.Level < minLevel {
+			minLevel = heading.Level
+		}
+	}
+
+	for _, heading := range headings {
+		fmt.Println(lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			styles.DateStyle.Render(fmt.Sprintf("L%-4d", heading.Line)),
+			" ",
+			strings.Repeat("  ", heading.Level-minLevel),
+			styles.ContentStyle.Render(heading.Text),
+			"  ",
+			lipgloss.NewStyle().Foreground(styles.Subtle).Render("#"+heading.Slug),
+		))
+	}
+}
+
+func init() {
+	addFormatFlag(tocCmd)
+}
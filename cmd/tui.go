@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/sk25469/jot/app"
+	"github.com/sk25469/jot/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse notes in a full-screen interface",
+	Long: `Browse notes full-screen: a note list with a live preview, mode and tag
+sidebars, a fuzzy filter (/) and full-text search (?). Enter opens the note
+under the cursor in your editor, n creates a note, t retags it and d deletes
+it. Tab moves between the list and the sidebar; q quits.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tui.Run(app.Instance.NoteService)
+	},
+}
@@ -3,14 +3,60 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Editor      string `mapstructure:"editor"`
-	DefaultMode string `mapstructure:"default_mode"`
-	StoragePath string `mapstructure:"storage_path"`
+	Editor          string                `mapstructure:"editor"`
+	EditorArgs      map[string]string     `mapstructure:"editor_args"`      // Editor name -> argument template with {file} and {line}
+	OutputTemplates map[string]string     `mapstructure:"output_templates"` // Named --template formats for list and search
+	DefaultMode     string                `mapstructure:"default_mode"`
+	StoragePath     string                `mapstructure:"storage_path"`
+	Tags            TagConfig             `mapstructure:"tags"`
+	Modes           map[string]ModeConfig `mapstructure:"modes"`
+	Daily           DailyConfig           `mapstructure:"daily"`
+	Review          ReviewConfig          `mapstructure:"review"`
+	Expiry          ExpiryConfig          `mapstructure:"expiry"`
+}
+
+// TagConfig controls how tags are normalised before they are stored
+type TagConfig struct {
+	CaseFold  bool              `mapstructure:"case_fold"` // Fold tags to lower case
+	Separator string            `mapstructure:"separator"` // Replaces runs of whitespace, '-' and '_'
+	NFC       bool              `mapstructure:"nfc"`       // Apply Unicode NFC normalisation
+	Synonyms  map[string]string `mapstructure:"synonyms"`  // Alias -> canonical tag
+}
+
+// DailyConfig controls how daily notes are created
+type DailyConfig struct {
+	Mode     string `mapstructure:"mode"`     // Mode for daily notes
+	Template string `mapstructure:"template"` // Template used the first time a day is opened
+}
+
+// ReviewConfig controls how review notes are created
+type ReviewConfig struct {
+	Mode     string `mapstructure:"mode"`     // Mode for review notes
+	Template string `mapstructure:"template"` // Template rendered with the review summary
+}
+
+// ExpiryConfig controls what happens to notes with a TTL
+type ExpiryConfig struct {
+	Action     string `mapstructure:"action"`      // "archive" or "trash" expired notes
+	WarnWithin string `mapstructure:"warn_within"` // Warn about notes expiring this soon, e.g. "1d"
+}
+
+// ModeConfig declares a note mode and its per-mode behaviour
+type ModeConfig struct {
+	Color          string   `mapstructure:"color"`           // Badge colour (e.g. "#00D4AA")
+	DefaultTags    []string `mapstructure:"default_tags"`    // Tags added to every new note
+	Directory      string   `mapstructure:"directory"`       // Subdirectory of the storage path
+	Editor         string   `mapstructure:"editor"`          // Overrides the global editor
+	RequiredFields []string `mapstructure:"required_fields"` // Frontmatter fields every note must fill in
+	Template       string   `mapstructure:"template"`        // Default template for new notes
+	TTL            string   `mapstructure:"ttl"`             // New notes expire after this, e.g. "7d"
 }
 
 var AppConfig Config
@@ -20,6 +66,15 @@ func InitConfig() error {
 	viper.SetDefault("editor", getDefaultEditor())
 	viper.SetDefault("default_mode", "dev")
 	viper.SetDefault("storage_path", getDefaultStoragePath())
+	viper.SetDefault("tags.case_fold", true)
+	viper.SetDefault("tags.separator", "-")
+	viper.SetDefault("tags.nfc", true)
+	viper.SetDefault("daily.mode", "journal")
+	viper.SetDefault("daily.template", "daily")
+	viper.SetDefault("review.mode", "journal")
+	viper.SetDefault("review.template", "review")
+	viper.SetDefault("expiry.action", "archive")
+	viper.SetDefault("expiry.warn_within", "1d")
 
 	// Config file settings
 	viper.SetConfigName("config")
@@ -38,6 +93,16 @@ func InitConfig() error {
 		return err
 	}
 
+	// Create templates directory if it doesn't exist
+	if err := os.MkdirAll(GetTemplatesDir(), 0755); err != nil {
+		return err
+	}
+
+	// Create assets directory if it doesn't exist
+	if err := os.MkdirAll(GetAssetsDir(), 0755); err != nil {
+		return err
+	}
+
 	// Read config file if it exists
 	if err := viper.ReadInConfig(); err != nil {
 		// Config file not found, create a default one
@@ -55,6 +120,14 @@ func InitConfig() error {
 		return err
 	}
 
+	// Fall back to the built-in modes and make sure the default mode is registered
+	if len(AppConfig.Modes) == 0 {
+		AppConfig.Modes = defaultModes()
+	}
+	if _, ok := AppConfig.Modes[AppConfig.DefaultMode]; !ok {
+		AppConfig.Modes[AppConfig.DefaultMode] = ModeConfig{}
+	}
+
 	// Expand storage path if it contains ~
 	if AppConfig.StoragePath[:2] == "~/" {
 		homeDir, _ := os.UserHomeDir()
@@ -86,11 +159,65 @@ func createDefaultConfig() error {
 	defaultConfig := `editor: "` + getDefaultEditor() + `"
 default_mode: "dev"
 storage_path: "` + getDefaultStoragePath() + `"
+modes:
+  dev:
+    color: "#00D4AA"
+  journal:
+    color: "#6B73FF"
+  meeting:
+    color: "#FFB86C"
 `
 
 	return os.WriteFile(configPath, []byte(defaultConfig), 0644)
 }
 
+func defaultModes() map[string]ModeConfig {
+	return map[string]ModeConfig{
+		"dev":     {Color: "#00D4AA"},
+		"journal": {Color: "#6B73FF"},
+		"meeting": {Color: "#FFB86C"},
+	}
+}
+
+// EditorOverride is the editor given with --editor, which wins over everything else
+var EditorOverride string
+
+// EditorCommand returns the editor command line for a mode: --editor first,
+// then the mode's editor, $VISUAL, $EDITOR and finally the configured editor
+func EditorCommand(mode string) string {
+	if EditorOverride != "" {
+		return EditorOverride
+	}
+	if modeCfg, ok := GetMode(mode); ok && modeCfg.Editor != "" {
+		return modeCfg.Editor
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if AppConfig.Editor != "" {
+		return AppConfig.Editor
+	}
+	return "vim"
+}
+
+// GetMode looks up a mode in the registry
+func GetMode(name string) (ModeConfig, bool) {
+	mode, ok := AppConfig.Modes[name]
+	return mode, ok
+}
+
+// ModeNames returns the registered mode names in sorted order
+func ModeNames() []string {
+	var names []string
+	for name := range AppConfig.Modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func GetNotesDir() string {
 	return AppConfig.StoragePath
 }
@@ -98,3 +225,19 @@ func GetNotesDir() string {
 func GetJotDir() string {
 	return getJotDir()
 }
+
+// GetTemplatesDir returns the directory holding note templates
+func GetTemplatesDir() string {
+	return filepath.Join(getJotDir(), "templates")
+}
+
+// GetAssetsDir returns the directory holding attached files
+func GetAssetsDir() string {
+	return filepath.Join(getJotDir(), "assets")
+}
+
+// GetTrashDir returns the directory expired notes are moved to when the
+// expiry action is "trash"
+func GetTrashDir() string {
+	return filepath.Join(getJotDir(), "trash")
+}
@@ -213,3 +213,71 @@ func TestPathExpansion(t *testing.T) {
 		})
 	}
 }
+
+func TestModeRegistry(t *testing.T) {
+	originalConfig := AppConfig
+	defer func() { AppConfig = originalConfig }()
+
+	AppConfig = Config{Modes: defaultModes()}
+
+	names := ModeNames()
+	expected := []string{"dev", "journal", "meeting"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("ModeNames() = %v, expected %v", names, expected)
+	}
+
+	mode, ok := GetMode("dev")
+	if !ok {
+		t.Fatalf("GetMode(\"dev\") should find the built-in dev mode")
+	}
+	if mode.Color == "" {
+		t.Errorf("Built-in dev mode should have a color")
+	}
+
+	if _, ok := GetMode("jurnal"); ok {
+		t.Errorf("GetMode(\"jurnal\") should not find an unregistered mode")
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	original := AppConfig
+	originalOverride := EditorOverride
+	defer func() {
+		AppConfig = original
+		EditorOverride = originalOverride
+	}()
+
+	AppConfig.Editor = "nano"
+	AppConfig.Modes = map[string]ModeConfig{
+		"dev":      {},
+		"incident": {Editor: "code --wait"},
+	}
+
+	testCases := []struct {
+		name     string
+		visual   string
+		editor   string
+		override string
+		mode     string
+		expected string
+	}{
+		{"config only", "", "", "", "dev", "nano"},
+		{"EDITOR beats config", "", "emacsclient -t", "", "dev", "emacsclient -t"},
+		{"VISUAL beats EDITOR", "code -w", "vim", "", "dev", "code -w"},
+		{"mode beats environment", "code -w", "vim", "", "incident", "code --wait"},
+		{"--editor beats mode", "code -w", "vim", "hx", "incident", "hx"},
+		{"unknown mode", "", "vim", "", "nope", "vim"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tc.visual)
+			t.Setenv("EDITOR", tc.editor)
+			EditorOverride = tc.override
+
+			if result := EditorCommand(tc.mode); result != tc.expected {
+				t.Errorf("EditorCommand(%q) = %q, expected %q", tc.mode, result, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+// storedTimeLayout is how the driver writes a time.Time to a DATETIME column
+const storedTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// AccessRepository handles database operations for the note access log
+type AccessRepository struct {
+	db *DB
+}
+
+// NewAccessRepository creates a new access repository
+func NewAccessRepository(db *DB) *AccessRepository {
+	return &AccessRepository{db: db}
+}
+
+// Record logs that a note was opened or shown
+func (r *AccessRepository) Record(noteID, action string, at time.Time) error {
+	_, err := r.db.conn.Exec(
+		"INSERT INTO note_access (note_id, action, accessed_at) VALUES (?, ?, ?)",
+		noteID, action, at.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record note access: %w", err)
+	}
+	return nil
+}
+
+// FrecencyBucket weighs each use of a note made since a time
+type FrecencyBucket struct {
+	Since  time.Time
+	Weight float64
+}
+
+// Frecency totals the access log per note: the last use, the number of uses
+// and the score, the sum of the weight of the first bucket each use falls in
+// or otherWeight for older ones. The notes come highest score first, with
+// only their IDs filled in. A limit of 0 returns them all.
+func (r *AccessRepository) Frecency(buckets []FrecencyBucket, otherWeight float64, limit int) ([]*models.RecentNote, error) {
+	var weight strings.Builder
+	var args []any
+	weight.WriteString("CASE")
+	for _, bucket := range buckets {
+		weight.WriteString(" WHEN accessed_at >= ? THEN ?")
+		args = append(args, bucket.Since.UTC(), bucket.Weight)
+	}
+	weight.WriteString(" ELSE ? END")
+	args = append(args, otherWeight)
+
+	query := `
+		SELECT note_id, COUNT(*) as uses, SUM(` + weight.String() + `) as score,
+			MAX(accessed_at) as last_used
+		FROM note_access
+		GROUP BY note_id
+		ORDER BY score DESC, last_used DESC`
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total note accesses: %w", err)
+	}
+	defer rows.Close()
+
+	var recent []*models.RecentNote
+	for rows.Next() {
+		note := &models.RecentNote{}
+		var lastUsed string
+		if err := rows.Scan(&note.ID, &note.Uses, &note.Score, &lastUsed); err != nil {
+			return nil, fmt.Errorf("failed to scan note accesses: %w", err)
+		}
+		// MAX() loses the column type, so the time comes back as the text
+		// the driver stored
+		if note.LastUsed, err = time.Parse(storedTimeLayout, lastUsed); err != nil {
+			return nil, fmt.Errorf("failed to parse last use: %w", err)
+		}
+		recent = append(recent, note)
+	}
+
+	return recent, rows.Err()
+}
+
+// Trim drops all but the keep most recent uses of a note, so the log stays
+// small however often a note is opened
+func (r *AccessRepository) Trim(noteID string, keep int) error {
+	_, err := r.db.conn.Exec(`
+		DELETE FROM note_access
+		WHERE note_id = ? AND rowid NOT IN (
+			SELECT rowid FROM note_access WHERE note_id = ?
+			ORDER BY accessed_at DESC, rowid DESC LIMIT ?
+		)`, noteID, noteID, keep)
+	if err != nil {
+		return fmt.Errorf("failed to trim note accesses: %w", err)
+	}
+	return nil
+}
+
+// Last returns the ID of the note used most recently, or "" when none was
+func (r *AccessRepository) Last() (string, error) {
+	var noteID string
+	err := r.db.conn.QueryRow(
+		"SELECT note_id FROM note_access ORDER BY accessed_at DESC, rowid DESC LIMIT 1").Scan(&noteID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get the last used note: %w", err)
+	}
+	return noteID, nil
+}
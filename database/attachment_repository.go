@@ -0,0 +1,78 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sk25469/jot/models"
+)
+
+// AttachmentRepository handles database operations for note attachments
+type AttachmentRepository struct {
+	db *DB
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// ReplaceForNoteTx replaces the attachments of a note inside a caller's transaction
+func (r *AttachmentRepository) ReplaceForNoteTx(tx *sql.Tx, noteID string, attachments []*models.Attachment) error {
+	if _, err := tx.Exec("DELETE FROM attachments WHERE note_id = ?", noteID); err != nil {
+		return fmt.Errorf("failed to delete existing attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		_, err := tx.Exec(
+			"INSERT INTO attachments (note_id, line, name, asset) VALUES (?, ?, ?, ?)",
+			noteID, attachment.Line, attachment.Name, attachment.Asset)
+		if err != nil {
+			return fmt.Errorf("failed to insert attachment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListForNote returns the attachments of a note in document order
+func (r *AttachmentRepository) ListForNote(noteID string) ([]*models.Attachment, error) {
+	rows, err := r.db.conn.Query(
+		"SELECT note_id, line, name, asset FROM attachments WHERE note_id = ? ORDER BY line",
+		noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*models.Attachment
+	for rows.Next() {
+		attachment := &models.Attachment{}
+		if err := rows.Scan(&attachment.NoteID, &attachment.Line, &attachment.Name, &attachment.Asset); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+// ReferencedAssets returns the set of asset file names linked from any note
+func (r *AttachmentRepository) ReferencedAssets() (map[string]bool, error) {
+	rows, err := r.db.conn.Query("SELECT DISTINCT asset FROM attachments")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referenced assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make(map[string]bool)
+	for rows.Next() {
+		var asset string
+		if err := rows.Scan(&asset); err != nil {
+			return nil, fmt.Errorf("failed to scan asset: %w", err)
+		}
+		assets[asset] = true
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ConfigRepository handles database operations for app settings
+type ConfigRepository struct {
+	db *DB
+}
+
+// NewConfigRepository creates a new config repository
+func NewConfigRepository(db *DB) *ConfigRepository {
+	return &ConfigRepository{db: db}
+}
+
+// Get retrieves a setting by key, returning an empty string if it is not set
+func (r *ConfigRepository) Get(key string) (string, error) {
+	var value string
+	err := r.db.conn.QueryRow("SELECT value FROM config WHERE key = ?", key).Scan(&value)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get config %s: %w", key, err)
+	}
+
+	return value, nil
+}
+
+// Set stores a setting, replacing any existing value
+func (r *ConfigRepository) Set(key, value string) error {
+	_, err := r.db.conn.Exec(
+		"INSERT OR REPLACE INTO config (key, value, updated_at) VALUES (?, ?, ?)",
+		key, value, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set config %s: %w", key, err)
+	}
+
+	return nil
+}
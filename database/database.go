@@ -119,6 +119,143 @@ func (db *DB) initializeSchema() error {
 	return nil
 }
 
+// migration upgrades the schema to the given version
+type migration struct {
+	version    string
+	statements []string
+}
+
+// migrations are applied in order to databases older than schema.sql
+var migrations = []migration{
+	{
+		version: "1.1",
+		statements: []string{
+			"ALTER TABLE note_tags ADD COLUMN source TEXT NOT NULL DEFAULT 'frontmatter'",
+		},
+	},
+	{
+		version: "1.2",
+		statements: []string{
+			`CREATE TABLE tasks (
+				id TEXT PRIMARY KEY,
+				note_id TEXT NOT NULL,
+				line INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				done INTEGER NOT NULL DEFAULT 0,
+				FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+			)`,
+			"CREATE INDEX idx_tasks_note ON tasks(note_id)",
+			"CREATE INDEX idx_tasks_done ON tasks(done)",
+		},
+	},
+	{
+		version: "1.3",
+		statements: []string{
+			"ALTER TABLE tasks ADD COLUMN due TEXT",
+			"ALTER TABLE tasks ADD COLUMN priority TEXT NOT NULL DEFAULT ''",
+			"ALTER TABLE tasks ADD COLUMN assignee TEXT NOT NULL DEFAULT ''",
+			"CREATE INDEX idx_tasks_due ON tasks(due)",
+		},
+	},
+	{
+		version: "1.4",
+		statements: []string{
+			`CREATE TABLE links (
+				source_id TEXT NOT NULL,
+				line INTEGER NOT NULL,
+				target TEXT NOT NULL,
+				alias TEXT NOT NULL DEFAULT '',
+				target_id TEXT,
+				FOREIGN KEY (source_id) REFERENCES notes(id) ON DELETE CASCADE
+			)`,
+			"CREATE INDEX idx_links_source ON links(source_id)",
+			"CREATE INDEX idx_links_target ON links(target_id)",
+		},
+	},
+	{
+		version: "1.5",
+		statements: []string{
+			"ALTER TABLE links ADD COLUMN anchor TEXT NOT NULL DEFAULT ''",
+			`CREATE TABLE headings (
+				note_id TEXT NOT NULL,
+				line INTEGER NOT NULL,
+				level INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				slug TEXT NOT NULL,
+				FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+			)`,
+			"CREATE INDEX idx_headings_note ON headings(note_id)",
+		},
+	},
+	{
+		version: "1.6",
+		statements: []string{
+			`CREATE TABLE attachments (
+				note_id TEXT NOT NULL,
+				line INTEGER NOT NULL,
+				name TEXT NOT NULL,
+				asset TEXT NOT NULL,
+				FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+			)`,
+			"CREATE INDEX idx_attachments_note ON attachments(note_id)",
+			"CREATE INDEX idx_attachments_asset ON attachments(asset)",
+		},
+	},
+	{
+		version: "1.7",
+		statements: []string{
+			`CREATE TABLE note_access (
+				note_id TEXT NOT NULL,
+				action TEXT NOT NULL,
+				accessed_at DATETIME NOT NULL,
+				FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+			)`,
+			"CREATE INDEX idx_note_access_note ON note_access(note_id)",
+			"CREATE INDEX idx_note_access_time ON note_access(accessed_at)",
+		},
+	},
+	{
+		version: "1.8",
+		statements: []string{
+			"ALTER TABLE notes ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT 0",
+			"ALTER TABLE notes ADD COLUMN archived BOOLEAN NOT NULL DEFAULT 0",
+			"CREATE INDEX idx_notes_archived ON notes(archived)",
+		},
+	},
+	{
+		version: "1.9",
+		statements: []string{
+			"ALTER TABLE notes ADD COLUMN expires_at DATETIME",
+			"CREATE INDEX idx_notes_expires ON notes(expires_at)",
+		},
+	},
+	{
+		// Task IDs are only unique within a note
+		version: "1.10",
+		statements: []string{
+			`CREATE TABLE tasks_new (
+				id TEXT NOT NULL,
+				note_id TEXT NOT NULL,
+				line INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				done INTEGER NOT NULL DEFAULT 0,
+				due TEXT,
+				priority TEXT NOT NULL DEFAULT '',
+				assignee TEXT NOT NULL DEFAULT '',
+				PRIMARY KEY (note_id, id),
+				FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+			)`,
+			`INSERT INTO tasks_new (id, note_id, line, text, done, due, priority, assignee)
+				SELECT id, note_id, line, text, done, due, priority, assignee FROM tasks`,
+			"DROP TABLE tasks",
+			"ALTER TABLE tasks_new RENAME TO tasks",
+			"CREATE INDEX idx_tasks_id ON tasks(id)",
+			"CREATE INDEX idx_tasks_done ON tasks(done)",
+			"CREATE INDEX idx_tasks_due ON tasks(due)",
+		},
+	},
+}
+
 // checkAndMigrate checks the database version and runs migrations if needed
 func (db *DB) checkAndMigrate() error {
 	var version string
@@ -127,15 +264,59 @@ func (db *DB) checkAndMigrate() error {
 		return fmt.Errorf("failed to get database version: %w", err)
 	}
 
-	// For now, we assume version 1.0 is current
-	// Future migrations would go here
+	// Databases created before versioning are at 1.0
 	if version == "" {
-		// Insert version if missing
-		_, err = db.conn.Exec("INSERT OR REPLACE INTO config (key, value) VALUES ('db_version', '1.0')")
-		if err != nil {
-			return fmt.Errorf("failed to set database version: %w", err)
+		version = "1.0"
+	}
+
+	for _, m := range migrations {
+		if compareVersions(version, m.version) >= 0 {
+			continue
+		}
+		if err := db.applyMigration(m); err != nil {
+			return err
 		}
+		version = m.version
+	}
+
+	_, err = db.conn.Exec("INSERT OR REPLACE INTO config (key, value) VALUES ('db_version', ?)", version)
+	if err != nil {
+		return fmt.Errorf("failed to set database version: %w", err)
 	}
 
 	return nil
 }
+
+// applyMigration runs a single migration in a transaction
+func (db *DB) applyMigration(m migration) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %s: %w", m.version, err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range m.statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.version, err)
+		}
+	}
+
+	_, err = tx.Exec("INSERT OR REPLACE INTO config (key, value) VALUES ('db_version', ?)", m.version)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", m.version, err)
+	}
+
+	return tx.Commit()
+}
+
+// compareVersions compares two "major.minor" version strings
+func compareVersions(a, b string) int {
+	var aMajor, aMinor, bMajor, bMinor int
+	fmt.Sscanf(a, "%d.%d", &aMajor, &aMinor)
+	fmt.Sscanf(b, "%d.%d", &bMajor, &bMinor)
+
+	if aMajor != bMajor {
+		return aMajor - bMajor
+	}
+	return aMinor - bMinor
+}
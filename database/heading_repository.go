@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sk25469/jot/models"
+)
+
+// HeadingRepository handles database operations for note headings
+type HeadingRepository struct {
+	db *DB
+}
+
+// NewHeadingRepository creates a new heading repository
+func NewHeadingRepository(db *DB) *HeadingRepository {
+	return &HeadingRepository{db: db}
+}
+
+// ReplaceForNoteTx replaces the headings of a note inside a caller's transaction
+func (r *HeadingRepository) ReplaceForNoteTx(tx *sql.Tx, noteID string, headings []*models.Heading) error {
+	if _, err := tx.Exec("DELETE FROM headings WHERE note_id = ?", noteID); err != nil {
+		return fmt.Errorf("failed to delete existing headings: %w", err)
+	}
+
+	for _, heading := range headings {
+		_, err := tx.Exec(
+			"INSERT INTO headings (note_id, line, level, text, slug) VALUES (?, ?, ?, ?, ?)",
+			noteID, heading.Line, heading.Level, heading.Text, heading.Slug)
+		if err != nil {
+			return fmt.Errorf("failed to insert heading: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListForNote returns the headings of a note in document order
+func (r *HeadingRepository) ListForNote(noteID string) ([]*models.Heading, error) {
+	rows, err := r.db.conn.Query(
+		"SELECT note_id, line, level, text, slug FROM headings WHERE note_id = ? ORDER BY line",
+		noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list headings: %w", err)
+	}
+	defer rows.Close()
+
+	var headings []*models.Heading
+	for rows.Next() {
+		heading := &models.Heading{}
+		if err := rows.Scan(&heading.NoteID, &heading.Line, &heading.Level, &heading.Text, &heading.Slug); err != nil {
+			return nil, fmt.Errorf("failed to scan heading: %w", err)
+		}
+		headings = append(headings, heading)
+	}
+
+	return headings, nil
+}
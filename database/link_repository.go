@@ -0,0 +1,143 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sk25469/jot/models"
+)
+
+// LinkRepository handles database operations for links between notes
+type LinkRepository struct {
+	db *DB
+}
+
+// NewLinkRepository creates a new link repository
+func NewLinkRepository(db *DB) *LinkRepository {
+	return &LinkRepository{db: db}
+}
+
+// ReplaceForNoteTx replaces the links of a note inside a caller's transaction.
+// Links are stored unresolved; ResolveTargets fills in their targets.
+func (r *LinkRepository) ReplaceForNoteTx(tx *sql.Tx, noteID string, links []*models.Link) error {
+	if _, err := tx.Exec("DELETE FROM links WHERE source_id = ?", noteID); err != nil {
+		return fmt.Errorf("failed to delete existing links: %w", err)
+	}
+
+	for _, link := range links {
+		_, err := tx.Exec(
+			"INSERT INTO links (source_id, line, target, alias, anchor) VALUES (?, ?, ?, ?, ?)",
+			noteID, link.Line, link.Target, link.Alias, link.Anchor)
+		if err != nil {
+			return fmt.Errorf("failed to insert link: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Targets returns the distinct link targets
+func (r *LinkRepository) Targets() ([]string, error) {
+	rows, err := r.db.conn.Query("SELECT DISTINCT target FROM links")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list link targets: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []string
+	for rows.Next() {
+		var target string
+		if err := rows.Scan(&target); err != nil {
+			return nil, fmt.Errorf("failed to scan link target: %w", err)
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// ResolveTargets sets the resolved note ID for each target, where an empty
+// ID marks the target as broken
+func (r *LinkRepository) ResolveTargets(resolved map[string]string) error {
+	tx, err := r.db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for target, targetID := range resolved {
+		var id interface{}
+		if targetID != "" {
+			id = targetID
+		}
+
+		_, err := tx.Exec(
+			"UPDATE links SET target_id = ? WHERE target = ? AND target_id IS NOT ?",
+			id, target, id)
+		if err != nil {
+			return fmt.Errorf("failed to resolve link: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListOutgoing returns the links found in a note
+func (r *LinkRepository) ListOutgoing(noteID string) ([]*models.Link, error) {
+	return r.queryLinks("WHERE l.source_id = ? ORDER BY l.line", noteID)
+}
+
+// ListIncoming returns the links pointing at a note
+func (r *LinkRepository) ListIncoming(noteID string) ([]*models.Link, error) {
+	return r.queryLinks("WHERE l.target_id = ? ORDER BY s.created_at DESC, l.line", noteID)
+}
+
+// ListResolved returns every link whose target resolves to a note
+func (r *LinkRepository) ListResolved() ([]*models.Link, error) {
+	return r.queryLinks("WHERE l.target_id IS NOT NULL ORDER BY l.source_id, l.line")
+}
+
+// ListBroken returns the links whose target could not be resolved, or whose
+// #anchor does not match a heading in the target note
+func (r *LinkRepository) ListBroken() ([]*models.Link, error) {
+	return r.queryLinks(`
+		WHERE l.target_id IS NULL
+			OR (l.anchor != '' AND NOT EXISTS (
+				SELECT 1 FROM headings h WHERE h.note_id = l.target_id
+					AND substr(h.slug, 1, length(l.anchor)) = l.anchor))
+		ORDER BY s.created_at DESC, l.line`)
+}
+
+// queryLinks runs a link query with the given conditions and scans the results
+func (r *LinkRepository) queryLinks(conditions string, args ...interface{}) ([]*models.Link, error) {
+	query := `
+		SELECT l.source_id, l.line, l.target, l.alias, l.anchor, l.target_id,
+			s.title, t.title
+		FROM links l
+		JOIN notes s ON l.source_id = s.id
+		LEFT JOIN notes t ON l.target_id = t.id
+		` + conditions
+
+	rows, err := r.db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*models.Link
+	for rows.Next() {
+		link := &models.Link{}
+		var targetID, targetTitle sql.NullString
+		err := rows.Scan(
+			&link.SourceID, &link.Line, &link.Target, &link.Alias, &link.Anchor, &targetID,
+			&link.SourceTitle, &targetTitle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		link.TargetID = targetID.String
+		link.TargetTitle = targetTitle.String
+		links = append(links, link)
+	}
+
+	return links, nil
+}
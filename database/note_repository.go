@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/sk25469/jot/models"
 )
@@ -35,20 +36,20 @@ func (r *NoteRepository) Create(note *models.Note) error {
 	// Insert note
 	query := `
 		INSERT INTO notes (id, title, mode, file_path, file_name, content_hash, 
-			created_at, updated_at, content_preview, word_count)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+			created_at, updated_at, content_preview, word_count, pinned, archived, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err = tx.Exec(query,
 		note.ID, note.Title, note.Mode, note.FilePath, note.FileName,
 		note.ContentHash, note.CreatedAt, note.UpdatedAt,
-		note.ContentPreview, note.WordCount)
+		note.ContentPreview, note.WordCount, note.Pinned, note.Archived, note.ExpiresAt)
 	if err != nil {
 		return fmt.Errorf("failed to insert note: %w", err)
 	}
 
 	// Insert tags if any
 	if len(note.Tags) > 0 {
-		if err := r.insertTagsForNote(tx, note.ID, note.Tags); err != nil {
+		if err := r.insertTagsForNote(tx, note); err != nil {
 			return fmt.Errorf("failed to insert tags: %w", err)
 		}
 	}
@@ -64,16 +65,25 @@ func (r *NoteRepository) Update(note *models.Note) error {
 	}
 	defer tx.Rollback()
 
+	if err := r.UpdateTx(tx, note); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateTx updates an existing note inside a caller's transaction
+func (r *NoteRepository) UpdateTx(tx *sql.Tx, note *models.Note) error {
 	// Update note
 	query := `
 		UPDATE notes 
 		SET title = ?, mode = ?, content_hash = ?, updated_at = ?, 
-			content_preview = ?, word_count = ?
+			content_preview = ?, word_count = ?, pinned = ?, archived = ?, expires_at = ?
 		WHERE id = ?`
 
-	_, err = tx.Exec(query,
+	_, err := tx.Exec(query,
 		note.Title, note.Mode, note.ContentHash, note.UpdatedAt,
-		note.ContentPreview, note.WordCount, note.ID)
+		note.ContentPreview, note.WordCount, note.Pinned, note.Archived, note.ExpiresAt, note.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update note: %w", err)
 	}
@@ -84,12 +94,12 @@ func (r *NoteRepository) Update(note *models.Note) error {
 	}
 
 	if len(note.Tags) > 0 {
-		if err := r.insertTagsForNote(tx, note.ID, note.Tags); err != nil {
+		if err := r.insertTagsForNote(tx, note); err != nil {
 			return fmt.Errorf("failed to insert updated tags: %w", err)
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // GetByID retrieves a note by its ID
@@ -97,7 +107,7 @@ func (r *NoteRepository) GetByID(id string) (*models.Note, error) {
 	query := `
 		SELECT n.id, n.title, n.mode, n.file_path, n.file_name, n.content_hash,
 			n.created_at, n.updated_at, n.content_preview, n.word_count,
-			COALESCE(GROUP_CONCAT(t.name, ','), '') as tags
+			n.pinned, n.archived, n.expires_at, COALESCE(GROUP_CONCAT(t.name, ','), '') as tags
 		FROM notes n
 		LEFT JOIN note_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
@@ -108,11 +118,12 @@ func (r *NoteRepository) GetByID(id string) (*models.Note, error) {
 
 	note := &models.Note{}
 	var tagsStr string
+	var expiresAt sql.NullTime
 
 	err := row.Scan(
 		&note.ID, &note.Title, &note.Mode, &note.FilePath, &note.FileName,
 		&note.ContentHash, &note.CreatedAt, &note.UpdatedAt,
-		&note.ContentPreview, &note.WordCount, &tagsStr)
+		&note.ContentPreview, &note.WordCount, &note.Pinned, &note.Archived, &expiresAt, &tagsStr)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -125,6 +136,7 @@ func (r *NoteRepository) GetByID(id string) (*models.Note, error) {
 	if tagsStr != "" {
 		note.Tags = strings.Split(tagsStr, ",")
 	}
+	note.ExpiresAt = nullTime(expiresAt)
 
 	return note, nil
 }
@@ -134,7 +146,7 @@ func (r *NoteRepository) List(filter models.ListFilter) ([]*models.Note, error)
 	query := `
 		SELECT n.id, n.title, n.mode, n.file_path, n.file_name, n.content_hash,
 			n.created_at, n.updated_at, n.content_preview, n.word_count,
-			COALESCE(GROUP_CONCAT(t.name, ','), '') as tags
+			n.pinned, n.archived, n.expires_at, COALESCE(GROUP_CONCAT(t.name, ','), '') as tags
 		FROM notes n
 		LEFT JOIN note_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id`
@@ -158,6 +170,15 @@ func (r *NoteRepository) List(filter models.ListFilter) ([]*models.Note, error)
 		args = append(args, filter.Until)
 	}
 
+	if condition := archivedCondition(filter.Archived); condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	if filter.ExpiresBy != nil {
+		conditions = append(conditions, "n.expires_at IS NOT NULL AND n.expires_at <= ?")
+		args = append(args, filter.ExpiresBy)
+	}
+
 	// Handle tag filtering
 	if len(filter.Tags) > 0 {
 		tagPlaceholders := strings.Repeat("?,", len(filter.Tags)-1) + "?"
@@ -190,7 +211,11 @@ func (r *NoteRepository) List(filter models.ListFilter) ([]*models.Note, error)
 		sortOrder = "ASC"
 	}
 
-	query += fmt.Sprintf(" ORDER BY %s %s", sortColumn, sortOrder)
+	query += " ORDER BY "
+	if filter.PinnedFirst {
+		query += "n.pinned DESC, "
+	}
+	query += fmt.Sprintf("%s %s", sortColumn, sortOrder)
 
 	// Add pagination
 	if filter.Limit > 0 {
@@ -213,11 +238,12 @@ func (r *NoteRepository) List(filter models.ListFilter) ([]*models.Note, error)
 	for rows.Next() {
 		note := &models.Note{}
 		var tagsStr string
+		var expiresAt sql.NullTime
 
 		err := rows.Scan(
 			&note.ID, &note.Title, &note.Mode, &note.FilePath, &note.FileName,
 			&note.ContentHash, &note.CreatedAt, &note.UpdatedAt,
-			&note.ContentPreview, &note.WordCount, &tagsStr)
+			&note.ContentPreview, &note.WordCount, &note.Pinned, &note.Archived, &expiresAt, &tagsStr)
 
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan note: %w", err)
@@ -227,6 +253,7 @@ func (r *NoteRepository) List(filter models.ListFilter) ([]*models.Note, error)
 		if tagsStr != "" {
 			note.Tags = strings.Split(tagsStr, ",")
 		}
+		note.ExpiresAt = nullTime(expiresAt)
 
 		notes = append(notes, note)
 	}
@@ -234,25 +261,39 @@ func (r *NoteRepository) List(filter models.ListFilter) ([]*models.Note, error)
 	return notes, nil
 }
 
-// Search performs full-text search on notes
-func (r *NoteRepository) Search(query string) ([]*models.SearchResult, error) {
+// Search performs full-text search on notes, keeping those the archive
+// filter selects. Only a database without the FTS table falls back to a
+// LIKE search; a query FTS can't parse is an error.
+func (r *NoteRepository) Search(query string, archived models.ArchiveFilter) ([]*models.SearchResult, error) {
+	// bm25() only works in a plain query on the FTS table, so the matches are
+	// ranked in a materialised CTE and grouped per note outside it, in case a
+	// note has more than one FTS row
 	searchQuery := `
+		WITH matches AS MATERIALIZED (
+			SELECT note_id, bm25(notes_fts) as rank
+			FROM notes_fts
+			WHERE notes_fts MATCH ?
+		)
 		SELECT n.id, n.title, n.mode, n.file_path, n.file_name, n.content_hash,
 			n.created_at, n.updated_at, n.content_preview, n.word_count,
-			COALESCE(GROUP_CONCAT(t.name, ','), '') as tags,
-			fts.bm25(fts) as rank, 'fts' as match_type
-		FROM notes_fts fts
-		JOIN notes n ON fts.note_id = n.id
-		LEFT JOIN note_tags nt ON n.id = nt.note_id
-		LEFT JOIN tags t ON nt.tag_id = t.id
-		WHERE notes_fts MATCH ?
+			n.pinned, n.archived, n.expires_at,
+			COALESCE((
+				SELECT GROUP_CONCAT(t.name, ',')
+				FROM note_tags nt JOIN tags t ON nt.tag_id = t.id
+				WHERE nt.note_id = n.id
+			), '') as tags,
+			MIN(m.rank) as rank, 'fts' as match_type
+		FROM matches m
+		JOIN notes n ON m.note_id = n.id` + andCondition(archivedCondition(archived)) + `
 		GROUP BY n.id
 		ORDER BY rank ASC`
 
-	rows, err := r.db.conn.Query(searchQuery, query)
+	rows, err := r.db.conn.Query(searchQuery, ftsQuery(query))
 	if err != nil {
-		// Fallback to simple LIKE search if FTS fails
-		return r.fallbackSearch(query)
+		if isMissingFTS(err) {
+			return r.fallbackSearch(query, archived)
+		}
+		return nil, fmt.Errorf("failed to search notes: %w", err)
 	}
 	defer rows.Close()
 
@@ -260,11 +301,12 @@ func (r *NoteRepository) Search(query string) ([]*models.SearchResult, error) {
 	for rows.Next() {
 		result := &models.SearchResult{}
 		var tagsStr string
+		var expiresAt sql.NullTime
 
 		err := rows.Scan(
 			&result.ID, &result.Title, &result.Mode, &result.FilePath, &result.FileName,
 			&result.ContentHash, &result.CreatedAt, &result.UpdatedAt,
-			&result.ContentPreview, &result.WordCount, &tagsStr,
+			&result.ContentPreview, &result.WordCount, &result.Pinned, &result.Archived, &expiresAt, &tagsStr,
 			&result.Rank, &result.MatchType)
 
 		if err != nil {
@@ -275,6 +317,7 @@ func (r *NoteRepository) Search(query string) ([]*models.SearchResult, error) {
 		if tagsStr != "" {
 			result.Tags = strings.Split(tagsStr, ",")
 		}
+		result.ExpiresAt = nullTime(expiresAt)
 
 		// Generate snippet from content preview
 		result.Snippet = r.generateSnippet(result.ContentPreview, query)
@@ -282,6 +325,10 @@ func (r *NoteRepository) Search(query string) ([]*models.SearchResult, error) {
 		results = append(results, result)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search notes: %w", err)
+	}
+
 	return results, nil
 }
 
@@ -334,13 +381,7 @@ func (r *NoteRepository) Delete(id string) error {
 	}
 	defer tx.Rollback()
 
-	// Delete note (cascades to note_tags due to foreign key)
-	_, err = tx.Exec("DELETE FROM notes WHERE id = ?", id)
-	if err != nil {
-		return fmt.Errorf("failed to delete note: %w", err)
-	}
-
-	// Update tag usage counts
+	// Update tag usage counts while the note's tags are still linked
 	_, err = tx.Exec(`
 		UPDATE tags SET usage_count = usage_count - 1 
 		WHERE id IN (
@@ -350,12 +391,35 @@ func (r *NoteRepository) Delete(id string) error {
 		return fmt.Errorf("failed to update tag usage counts: %w", err)
 	}
 
+	// Delete note (cascades to note_tags due to foreign key)
+	_, err = tx.Exec("DELETE FROM notes WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+
 	return tx.Commit()
 }
 
-// insertTagsForNote handles tag insertion for a note
-func (r *NoteRepository) insertTagsForNote(tx *sql.Tx, noteID string, tags []string) error {
-	for _, tagName := range tags {
+// PruneUnusedTags removes tags that are no longer attached to any note
+func (r *NoteRepository) PruneUnusedTags() error {
+	_, err := r.db.conn.Exec(`
+		DELETE FROM tags
+		WHERE id NOT IN (SELECT DISTINCT tag_id FROM note_tags)`)
+	if err != nil {
+		return fmt.Errorf("failed to prune unused tags: %w", err)
+	}
+
+	return nil
+}
+
+// insertTagsForNote handles tag insertion for a note, recording where each tag came from
+func (r *NoteRepository) insertTagsForNote(tx *sql.Tx, note *models.Note) error {
+	bodyTags := make(map[string]bool)
+	for _, tagName := range note.BodyTags {
+		bodyTags[tagName] = true
+	}
+
+	for _, tagName := range note.Tags {
 		// Get or create tag
 		var tagID int
 		err := tx.QueryRow("SELECT id FROM tags WHERE name = ?", tagName).Scan(&tagID)
@@ -384,8 +448,14 @@ func (r *NoteRepository) insertTagsForNote(tx *sql.Tx, noteID string, tags []str
 			}
 		}
 
+		source := "frontmatter"
+		if bodyTags[tagName] {
+			source = "body"
+		}
+
 		// Create note-tag relationship
-		_, err = tx.Exec("INSERT INTO note_tags (note_id, tag_id) VALUES (?, ?)", noteID, tagID)
+		_, err = tx.Exec("INSERT INTO note_tags (note_id, tag_id, source) VALUES (?, ?, ?)",
+			note.ID, tagID, source)
 		if err != nil {
 			return fmt.Errorf("failed to create note-tag relationship: %w", err)
 		}
@@ -395,15 +465,15 @@ func (r *NoteRepository) insertTagsForNote(tx *sql.Tx, noteID string, tags []str
 }
 
 // fallbackSearch provides simple LIKE-based search when FTS is not available
-func (r *NoteRepository) fallbackSearch(query string) ([]*models.SearchResult, error) {
+func (r *NoteRepository) fallbackSearch(query string, archived models.ArchiveFilter) ([]*models.SearchResult, error) {
 	searchQuery := `
 		SELECT n.id, n.title, n.mode, n.file_path, n.file_name, n.content_hash,
 			n.created_at, n.updated_at, n.content_preview, n.word_count,
-			COALESCE(GROUP_CONCAT(t.name, ','), '') as tags
+			n.pinned, n.archived, n.expires_at, COALESCE(GROUP_CONCAT(t.name, ','), '') as tags
 		FROM notes n
 		LEFT JOIN note_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
-		WHERE n.title LIKE ? OR n.content_preview LIKE ? OR t.name LIKE ?
+		WHERE (n.title LIKE ? OR n.content_preview LIKE ? OR t.name LIKE ?)` + andCondition(archivedCondition(archived)) + `
 		GROUP BY n.id
 		ORDER BY n.updated_at DESC`
 
@@ -418,11 +488,12 @@ func (r *NoteRepository) fallbackSearch(query string) ([]*models.SearchResult, e
 	for rows.Next() {
 		result := &models.SearchResult{}
 		var tagsStr string
+		var expiresAt sql.NullTime
 
 		err := rows.Scan(
 			&result.ID, &result.Title, &result.Mode, &result.FilePath, &result.FileName,
 			&result.ContentHash, &result.CreatedAt, &result.UpdatedAt,
-			&result.ContentPreview, &result.WordCount, &tagsStr)
+			&result.ContentPreview, &result.WordCount, &result.Pinned, &result.Archived, &expiresAt, &tagsStr)
 
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan fallback search result: %w", err)
@@ -432,6 +503,7 @@ func (r *NoteRepository) fallbackSearch(query string) ([]*models.SearchResult, e
 		if tagsStr != "" {
 			result.Tags = strings.Split(tagsStr, ",")
 		}
+		result.ExpiresAt = nullTime(expiresAt)
 
 		result.Rank = 1.0
 		result.MatchType = "fallback"
@@ -445,3 +517,97 @@ func (r *NoteRepository) fallbackSearch(query string) ([]*models.SearchResult, e
 
 	return results, nil
 }
+
+// archivedCondition is the WHERE condition for an archive filter, empty when
+// it keeps every note
+func archivedCondition(filter models.ArchiveFilter) string {
+	switch filter {
+	case models.ExcludeArchived:
+		return "n.archived = 0"
+	case models.OnlyArchived:
+		return "n.archived = 1"
+	}
+	return ""
+}
+
+// nullTime converts a nullable timestamp column to a time pointer
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// ftsQuery quotes each word of a search query that FTS5 would not read as a
+// plain term, so that offset-reset, v1.2 or user@example.com are searched as
+// phrases rather than parsed as column filters or syntax errors. Quoted
+// phrases, parentheses, operators and prefix searches like kaf* are kept.
+func ftsQuery(query string) string {
+	var b strings.Builder
+	inPhrase := false
+	word := 0
+	flush := func(end int) {
+		if end > word {
+			b.WriteString(ftsWord(query[word:end]))
+		}
+	}
+
+	for i, r := range query {
+		switch {
+		case r == '"':
+			if !inPhrase {
+				flush(i)
+			}
+			inPhrase = !inPhrase
+			b.WriteRune(r)
+			word = i + 1
+		case inPhrase:
+			b.WriteRune(r)
+			word = i + 1
+		case unicode.IsSpace(r) || r == '(' || r == ')':
+			flush(i)
+			b.WriteRune(r)
+			word = i + 1
+		}
+	}
+	if !inPhrase {
+		flush(len(query))
+	}
+
+	return b.String()
+}
+
+// ftsWord returns a query word as is when FTS5 reads it as a term, operator
+// or prefix search, and as a quoted phrase otherwise
+func ftsWord(word string) string {
+	switch word {
+	case "AND", "OR", "NOT", "NEAR":
+		return word
+	}
+	term := strings.TrimSuffix(word, "*")
+	if term == "" {
+		return `"` + word + `"`
+	}
+	// FTS5 barewords are ASCII letters, digits and _, and any non-ASCII
+	// character
+	for _, r := range term {
+		if r <= unicode.MaxASCII && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return `"` + word + `"`
+		}
+	}
+	return word
+}
+
+// isMissingFTS reports whether an error comes from a database without the
+// notes_fts table, e.g. an SQLite build without FTS5
+func isMissingFTS(err error) bool {
+	return strings.Contains(err.Error(), "no such table: notes_fts") || strings.Contains(err.Error(), "no such module: fts5")
+}
+
+// andCondition prefixes a non-empty condition with AND to extend a WHERE clause
+func andCondition(condition string) string {
+	if condition == "" {
+		return ""
+	}
+	return " AND " + condition
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := New(Config{Path: filepath.Join(t.TempDir(), "jot.db")})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSearchUsesFTS(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewNoteRepository(db)
+
+	now := time.Now().UTC()
+	notes := []struct {
+		note    *models.Note
+		content string
+	}{
+		{&models.Note{ID: "a1", Title: "Runbook", Mode: "dev", FilePath: "/a1.md", FileName: "a1.md", CreatedAt: now, UpdatedAt: now, ContentPreview: "Intro"},
+			"Intro\n\n## Rollback\n\nRun zanzibar twice."},
+		{&models.Note{ID: "b2", Title: "Other", Mode: "dev", FilePath: "/b2.md", FileName: "b2.md", CreatedAt: now, UpdatedAt: now, Archived: true},
+			"zanzibar in an archived note"},
+	}
+	for _, n := range notes {
+		if err := repo.Create(n.note); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+		if _, err := db.conn.Exec("INSERT INTO notes_fts (note_id, title, content, tags) VALUES (?, ?, ?, '')",
+			n.note.ID, n.note.Title, n.content); err != nil {
+			t.Fatalf("indexing %s: %v", n.note.ID, err)
+		}
+	}
+
+	// The term is only in the indexed content, not in the stored preview
+	results, err := repo.Search("zanzibar", models.ExcludeArchived)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "a1" {
+		t.Fatalf("Search() = %v, expected only a1", results)
+	}
+	if results[0].MatchType != "fts" {
+		t.Errorf("Search() match_type = %q, expected fts", results[0].MatchType)
+	}
+
+	all, err := repo.Search("zanzibar", models.IncludeArchived)
+	if err != nil || len(all) != 2 {
+		t.Errorf("Search(IncludeArchived) = %d results, %v, expected 2", len(all), err)
+	}
+
+	// A note indexed twice, as older versions did on every edit, is one result
+	if _, err := db.conn.Exec("INSERT INTO notes_fts (note_id, title, content, tags) VALUES ('a1', 'Runbook', 'zanzibar again', '')"); err != nil {
+		t.Fatalf("indexing a1 again: %v", err)
+	}
+	if again, err := repo.Search("zanzibar", models.ExcludeArchived); err != nil || len(again) != 1 {
+		t.Errorf("Search() with a duplicate FTS row = %d results, %v, expected 1", len(again), err)
+	}
+
+	// An unbalanced quote is an FTS syntax error, not a silent LIKE search
+	if _, err := repo.Search(`"zanzibar`, models.IncludeArchived); err == nil {
+		t.Errorf("Search() with an unterminated phrase returned no error")
+	}
+}
+
+func TestFTSQuery(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"kafka offsets", "kafka offsets"},
+		{"incident-response", `"incident-response"`},
+		{"v1.2 foo.bar", `"v1.2" "foo.bar"`},
+		{"C++ user@example.com", `"C++" "user@example.com"`},
+		{"kaf* AND (lag OR offset-reset)", `kaf* AND (lag OR "offset-reset")`},
+		{`"offset reset" NOT café`, `"offset reset" NOT café`},
+		{"(ir OR \"incident-response\")", "(ir OR \"incident-response\")"},
+	}
+
+	for _, tc := range testCases {
+		if got := ftsQuery(tc.query); got != tc.expected {
+			t.Errorf("ftsQuery(%q) = %q, expected %q", tc.query, got, tc.expected)
+		}
+	}
+}
+
+func TestSearchPunctuatedTerms(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewNoteRepository(db)
+
+	now := time.Now().UTC()
+	note := &models.Note{ID: "a1", Title: "Postmortem", Mode: "dev", FilePath: "/a1.md", FileName: "a1.md", CreatedAt: now, UpdatedAt: now}
+	if err := repo.Create(note); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if _, err := db.conn.Exec("INSERT INTO notes_fts (note_id, title, content, tags) VALUES (?, ?, ?, ?)",
+		note.ID, note.Title, "The offset-reset in v1.2 broke foo.bar in C++; mail user@example.com", "incident-response"); err != nil {
+		t.Fatalf("indexing a1: %v", err)
+	}
+
+	for _, query := range []string{"incident-response", "offset-reset", "v1.2", "foo.bar", "C++", "user@example.com"} {
+		results, err := repo.Search(query, models.IncludeArchived)
+		if err != nil {
+			t.Errorf("Search(%q) error: %v", query, err)
+			continue
+		}
+		if len(results) != 1 || results[0].MatchType != "fts" {
+			t.Errorf("Search(%q) = %d results, expected the note from FTS", query, len(results))
+		}
+	}
+}
@@ -12,7 +12,10 @@ CREATE TABLE notes (
     created_at DATETIME NOT NULL,  -- When note was created
     updated_at DATETIME NOT NULL,  -- When note was last modified
     content_preview TEXT,          -- First 200 chars of content for quick display
-    word_count INTEGER DEFAULT 0   -- Number of words in the note
+    word_count INTEGER DEFAULT 0,  -- Number of words in the note
+    pinned BOOLEAN NOT NULL DEFAULT 0,   -- Listed first (frontmatter 'pinned: true')
+    archived BOOLEAN NOT NULL DEFAULT 0, -- Hidden by default (frontmatter 'archived: true')
+    expires_at DATETIME                  -- Archived or trashed after this (frontmatter 'expires')
 );
 
 -- Tags table - normalized tag storage
@@ -27,6 +30,7 @@ CREATE TABLE tags (
 CREATE TABLE note_tags (
     note_id TEXT NOT NULL,         -- References notes.id
     tag_id INTEGER NOT NULL,       -- References tags.id
+    source TEXT NOT NULL DEFAULT 'frontmatter', -- Where the tag came from (frontmatter, body)
     PRIMARY KEY (note_id, tag_id),
     FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
     FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
@@ -67,6 +71,58 @@ CREATE VIRTUAL TABLE notes_fts USING fts5(
 --     DELETE FROM notes_fts WHERE note_id = OLD.id;
 -- END;
 
+-- Tasks table - Markdown checkbox items parsed from note bodies
+CREATE TABLE tasks (
+    id TEXT NOT NULL,              -- 7-char hash of note ID, text and occurrence
+    note_id TEXT NOT NULL,         -- References notes.id
+    line INTEGER NOT NULL,         -- 1-based line number in the .md file
+    text TEXT NOT NULL,            -- Task text without the checkbox
+    done INTEGER NOT NULL DEFAULT 0,   -- 1 if the box is ticked
+    due TEXT,                      -- Due date as YYYY-MM-DD, from due:2025-11-03
+    priority TEXT NOT NULL DEFAULT '',  -- high, medium or low, from !high
+    assignee TEXT NOT NULL DEFAULT '',  -- From @alice
+    PRIMARY KEY (note_id, id),     -- IDs are only unique within a note
+    FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+-- Wiki-style [[links]] between notes
+CREATE TABLE links (
+    source_id TEXT NOT NULL,       -- Note containing the link
+    line INTEGER NOT NULL,         -- 1-based line number in the .md file
+    target TEXT NOT NULL,          -- Text inside [[...]] before any |alias
+    alias TEXT NOT NULL DEFAULT '',     -- Display text after the |
+    anchor TEXT NOT NULL DEFAULT '',    -- Heading slug after a #, if any
+    target_id TEXT,                -- Resolved note ID, NULL for broken links
+    FOREIGN KEY (source_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+-- Markdown headings, for outlines and #anchors
+CREATE TABLE headings (
+    note_id TEXT NOT NULL,         -- References notes.id
+    line INTEGER NOT NULL,         -- 1-based line number in the .md file
+    level INTEGER NOT NULL,        -- 1 for #, 2 for ## and so on
+    text TEXT NOT NULL,            -- Heading text without the #s
+    slug TEXT NOT NULL,            -- Anchor used by [[note#slug]] and jot open id#slug
+    FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+-- Files linked from notes that live in ~/.jot/assets
+CREATE TABLE attachments (
+    note_id TEXT NOT NULL,         -- References notes.id
+    line INTEGER NOT NULL,         -- 1-based line number of the link
+    name TEXT NOT NULL,            -- Link text, the original file name for jot attach
+    asset TEXT NOT NULL,           -- Content-hash file name in the assets directory
+    FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+-- Every time a note is opened or shown, for jot recent and frecency ranking
+CREATE TABLE note_access (
+    note_id TEXT NOT NULL,         -- References notes.id
+    action TEXT NOT NULL,          -- open or show
+    accessed_at DATETIME NOT NULL,
+    FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
 -- Configuration table for app settings
 CREATE TABLE config (
     key TEXT PRIMARY KEY,
@@ -79,7 +135,7 @@ INSERT INTO config (key, value) VALUES
     ('editor', 'vim'),
     ('default_mode', 'dev'),
     ('storage_path', '~/.jot/notes'),
-    ('db_version', '1.0');
+    ('db_version', '1.10');
 
 -- Views for common queries
 
@@ -124,5 +180,17 @@ CREATE INDEX idx_notes_updated_desc ON notes(updated_at DESC);
 CREATE INDEX idx_notes_mode ON notes(mode);
 CREATE INDEX idx_notes_title ON notes(title);
 CREATE INDEX idx_notes_mode_created ON notes(mode, created_at DESC);
+CREATE INDEX idx_notes_archived ON notes(archived);
+CREATE INDEX idx_notes_expires ON notes(expires_at);
 CREATE INDEX idx_tags_name ON tags(name);
-CREATE INDEX idx_tags_usage ON tags(usage_count DESC);
\ No newline at end of file
+CREATE INDEX idx_tags_usage ON tags(usage_count DESC);
+CREATE INDEX idx_tasks_id ON tasks(id);
+CREATE INDEX idx_tasks_done ON tasks(done);
+CREATE INDEX idx_tasks_due ON tasks(due);
+CREATE INDEX idx_links_source ON links(source_id);
+CREATE INDEX idx_links_target ON links(target_id);
+CREATE INDEX idx_headings_note ON headings(note_id);
+CREATE INDEX idx_attachments_note ON attachments(note_id);
+CREATE INDEX idx_attachments_asset ON attachments(asset);
+CREATE INDEX idx_note_access_note ON note_access(note_id);
+CREATE INDEX idx_note_access_time ON note_access(accessed_at);
@@ -75,7 +75,17 @@ func (r *StatsRepository) GetStats() (*models.StatsResult, error) {
 	}
 
 	// Get mode statistics
-	modeRows, err := r.db.conn.Query(`
+	stats.ModeStats, err = r.GetModeCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetModeCounts returns the number of notes in each mode
+func (r *StatsRepository) GetModeCounts() (map[string]int, error) {
+	rows, err := r.db.conn.Query(`
 		SELECT mode, COUNT(*) as count
 		FROM notes
 		GROUP BY mode
@@ -83,18 +93,19 @@ func (r *StatsRepository) GetStats() (*models.StatsResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get mode statistics: %w", err)
 	}
-	defer modeRows.Close()
+	defer rows.Close()
 
-	for modeRows.Next() {
+	modeCounts := make(map[string]int)
+	for rows.Next() {
 		var mode string
 		var count int
-		if err := modeRows.Scan(&mode, &count); err != nil {
+		if err := rows.Scan(&mode, &count); err != nil {
 			return nil, fmt.Errorf("failed to scan mode stats: %w", err)
 		}
-		stats.ModeStats[mode] = count
+		modeCounts[mode] = count
 	}
 
-	return stats, nil
+	return modeCounts, nil
 }
 
 // GetTagUsage returns the most used tags with their counts
@@ -132,7 +143,18 @@ func (r *StatsRepository) GetTagUsage(limit int) (map[string]int, error) {
 // GetRecentActivity returns notes created in the last N days
 func (r *StatsRepository) GetRecentActivity(days int) ([]*models.Note, error) {
 	since := time.Now().AddDate(0, 0, -days)
+	return r.queryActivity("n.created_at >= ?", since)
+}
+
+// GetActivityBetween returns notes created or updated within a time range
+func (r *StatsRepository) GetActivityBetween(since, until time.Time) ([]*models.Note, error) {
+	return r.queryActivity(
+		"((n.created_at >= ? AND n.created_at < ?) OR (n.updated_at >= ? AND n.updated_at < ?))",
+		since, until, since, until)
+}
 
+// queryActivity lists notes matching a condition, newest first
+func (r *StatsRepository) queryActivity(condition string, args ...interface{}) ([]*models.Note, error) {
 	query := `
 		SELECT n.id, n.title, n.mode, n.file_path, n.file_name, n.content_hash,
 			n.created_at, n.updated_at, n.content_preview, n.word_count,
@@ -140,11 +162,11 @@ func (r *StatsRepository) GetRecentActivity(days int) ([]*models.Note, error) {
 		FROM notes n
 		LEFT JOIN note_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
-		WHERE n.created_at >= ?
+		WHERE ` + condition + `
 		GROUP BY n.id
 		ORDER BY n.created_at DESC`
 
-	rows, err := r.db.conn.Query(query, since)
+	rows, err := r.db.conn.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent activity: %w", err)
 	}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+// dueLayout is the storage format of task due dates
+const dueLayout = "2006-01-02"
+
+// TaskRepository handles database operations for tasks
+type TaskRepository struct {
+	db *DB
+}
+
+// NewTaskRepository creates a new task repository
+func NewTaskRepository(db *DB) *TaskRepository {
+	return &TaskRepository{db: db}
+}
+
+// ReplaceForNoteTx replaces the tasks of a note inside a caller's transaction
+func (r *TaskRepository) ReplaceForNoteTx(tx *sql.Tx, noteID string, tasks []*models.Task) error {
+	if _, err := tx.Exec("DELETE FROM tasks WHERE note_id = ?", noteID); err != nil {
+		return fmt.Errorf("failed to delete existing tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		var due interface{}
+		if task.Due != nil {
+			due = task.Due.Format(dueLayout)
+		}
+
+		_, err := tx.Exec(
+			`INSERT INTO tasks (id, note_id, line, text, done, due, priority, assignee)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			task.ID, noteID, task.Line, task.Text, task.Done, due, task.Priority, task.Assignee)
+		if err != nil {
+			return fmt.Errorf("failed to insert task %s (%s): %w", task.ID, task.Text, err)
+		}
+	}
+
+	return nil
+}
+
+// List retrieves tasks with optional filtering, ordered by note and line
+func (r *TaskRepository) List(filter models.TaskFilter) ([]*models.Task, error) {
+	query := `
+		SELECT tk.id, tk.note_id, tk.line, tk.text, tk.done,
+			tk.due, tk.priority, tk.assignee, n.title, n.mode, n.file_path
+		FROM tasks tk
+		JOIN notes n ON tk.note_id = n.id`
+
+	var conditions []string
+	var args []interface{}
+
+	if !filter.IncludeDone {
+		conditions = append(conditions, "tk.done = 0")
+	}
+
+	if filter.Mode != "" {
+		conditions = append(conditions, "n.mode = ?")
+		args = append(args, filter.Mode)
+	}
+
+	if filter.NoteID != "" {
+		conditions = append(conditions, "tk.note_id = ?")
+		args = append(args, filter.NoteID)
+	}
+
+	if filter.Assignee != "" {
+		conditions = append(conditions, "tk.assignee = ?")
+		args = append(args, filter.Assignee)
+	}
+
+	if filter.DueOnly {
+		conditions = append(conditions, "tk.due IS NOT NULL")
+	}
+
+	if len(filter.Tags) > 0 {
+		tagPlaceholders := strings.Repeat("?,", len(filter.Tags)-1) + "?"
+		conditions = append(conditions, fmt.Sprintf(`n.id IN (
+			SELECT nt.note_id FROM note_tags nt
+			JOIN tags t ON nt.tag_id = t.id
+			WHERE t.name IN (%s))`, tagPlaceholders))
+		for _, tag := range filter.Tags {
+			args = append(args, tag)
+		}
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY n.created_at DESC, tk.line ASC"
+
+	return r.queryTasks(query, args...)
+}
+
+// FindByIDPrefix returns the tasks whose ID starts with the given prefix
+func (r *TaskRepository) FindByIDPrefix(prefix string) ([]*models.Task, error) {
+	query := `
+		SELECT tk.id, tk.note_id, tk.line, tk.text, tk.done,
+			tk.due, tk.priority, tk.assignee, n.title, n.mode, n.file_path
+		FROM tasks tk
+		JOIN notes n ON tk.note_id = n.id
+		WHERE tk.id LIKE ? || '%'
+		ORDER BY tk.id`
+
+	return r.queryTasks(query, prefix)
+}
+
+// queryTasks runs a task query and scans the results
+func (r *TaskRepository) queryTasks(query string, args ...interface{}) ([]*models.Task, error) {
+	rows, err := r.db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*models.Task
+	for rows.Next() {
+		task := &models.Task{}
+		var due sql.NullString
+		err := rows.Scan(
+			&task.ID, &task.NoteID, &task.Line, &task.Text, &task.Done,
+			&due, &task.Priority, &task.Assignee,
+			&task.NoteTitle, &task.NoteMode, &task.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		if due.Valid {
+			if day, err := time.ParseInLocation(dueLayout, due.String, time.Local); err == nil {
+				task.Due = &day
+			}
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+func TestReplaceTasksSameIDInTwoNotes(t *testing.T) {
+	db := newTestDB(t)
+	notes := NewNoteRepository(db)
+	repo := NewTaskRepository(db)
+
+	now := time.Now().UTC()
+	for _, id := range []string{"a1", "b2"} {
+		note := &models.Note{ID: id, Title: id, Mode: "dev", FilePath: "/" + id + ".md", FileName: id + ".md", CreatedAt: now, UpdatedAt: now}
+		if err := notes.Create(note); err != nil {
+			t.Fatalf("Create() error: %v", err)
+		}
+
+		tx, err := db.conn.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		task := &models.Task{ID: "abc1234", Line: 1, Text: "follow up in " + id}
+		if err := repo.ReplaceForNoteTx(tx, id, []*models.Task{task}); err != nil {
+			t.Fatalf("ReplaceForNoteTx(%s) error: %v", id, err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tasks, err := repo.FindByIDPrefix("abc1234")
+	if err != nil {
+		t.Fatalf("FindByIDPrefix() error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("FindByIDPrefix() = %d tasks, expected both notes' tasks to be kept", len(tasks))
+	}
+}
@@ -6,17 +6,21 @@ import (
 
 // Note represents a note in the database
 type Note struct {
-	ID             string    `db:"id" json:"id"`
-	Title          string    `db:"title" json:"title"`
-	Mode           string    `db:"mode" json:"mode"`
-	FilePath       string    `db:"file_path" json:"file_path"`
-	FileName       string    `db:"file_name" json:"file_name"`
-	ContentHash    string    `db:"content_hash" json:"content_hash"`
-	CreatedAt      time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
-	ContentPreview string    `db:"content_preview" json:"content_preview"`
-	WordCount      int       `db:"word_count" json:"word_count"`
-	Tags           []string  `json:"tags"` // Populated by joins, not stored directly
+	ID             string     `db:"id" json:"id"`
+	Title          string     `db:"title" json:"title"`
+	Mode           string     `db:"mode" json:"mode"`
+	FilePath       string     `db:"file_path" json:"file_path"`
+	FileName       string     `db:"file_name" json:"file_name"`
+	ContentHash    string     `db:"content_hash" json:"content_hash"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at" json:"updated_at"`
+	ContentPreview string     `db:"content_preview" json:"content_preview"`
+	WordCount      int        `db:"word_count" json:"word_count"`
+	Tags           []string   `json:"tags"`                                 // Populated by joins, not stored directly
+	BodyTags       []string   `json:"body_tags,omitempty"`                  // Subset of Tags found inline in the body
+	Pinned         bool       `db:"pinned" json:"pinned,omitempty"`         // Listed before other notes
+	Archived       bool       `db:"archived" json:"archived,omitempty"`     // Hidden from list and search by default
+	ExpiresAt      *time.Time `db:"expires_at" json:"expires_at,omitempty"` // Archived or trashed by sync after this
 }
 
 // Tag represents a tag in the database
@@ -33,6 +37,141 @@ type NoteTag struct {
 	TagID  int    `db:"tag_id" json:"tag_id"`
 }
 
+// Task represents a Markdown checkbox item in a note
+type Task struct {
+	ID        string     `db:"id" json:"id"`
+	NoteID    string     `db:"note_id" json:"note_id"`
+	Line      int        `db:"line" json:"line"`
+	Text      string     `db:"text" json:"text"`
+	Done      bool       `db:"done" json:"done"`
+	Due       *time.Time `db:"due" json:"due,omitempty"`
+	Priority  string     `db:"priority" json:"priority,omitempty"` // high, medium or low
+	Assignee  string     `db:"assignee" json:"assignee,omitempty"`
+	NoteTitle string     `json:"note_title"` // Populated by joins
+	NoteMode  string     `json:"note_mode"`  // Populated by joins
+	FilePath  string     `json:"file_path"`  // Populated by joins
+}
+
+// TaskFilter represents filtering options for listing tasks
+type TaskFilter struct {
+	Tags        []string
+	Mode        string
+	NoteID      string
+	Assignee    string
+	DueOnly     bool // Only tasks with a due date
+	IncludeDone bool
+}
+
+// AgendaDay groups the tasks due on one day
+type AgendaDay struct {
+	Day   time.Time
+	Tasks []*Task
+}
+
+// NoteGroup is a section of notes sharing a mode, tag, month, week or notebook
+type NoteGroup struct {
+	Name  string
+	Notes []*Note
+}
+
+// Link represents a wiki-style [[link]] from one note to another
+type Link struct {
+	SourceID    string `db:"source_id" json:"source_id"`
+	Line        int    `db:"line" json:"line"`
+	Target      string `db:"target" json:"target"`
+	Alias       string `db:"alias" json:"alias,omitempty"`
+	Anchor      string `db:"anchor" json:"anchor,omitempty"`       // Heading slug after a #
+	TargetID    string `db:"target_id" json:"target_id,omitempty"` // Empty for broken links
+	SourceTitle string `json:"source_title"`                       // Populated by joins
+	TargetTitle string `json:"target_title,omitempty"`             // Populated by joins
+}
+
+// Heading is a Markdown heading in a note
+type Heading struct {
+	NoteID string `db:"note_id" json:"note_id"`
+	Line   int    `db:"line" json:"line"`
+	Level  int    `db:"level" json:"level"`
+	Text   string `db:"text" json:"text"`
+	Slug   string `db:"slug" json:"slug"`
+}
+
+// Attachment is a file in the assets directory linked from a note
+type Attachment struct {
+	NoteID  string `db:"note_id" json:"note_id"`
+	Line    int    `db:"line" json:"line"`
+	Name    string `db:"name" json:"name"`   // Link text
+	Asset   string `db:"asset" json:"asset"` // Content-hash file name
+	Path    string `json:"path"`             // Full path of the asset
+	Size    int64  `json:"size"`             // Bytes, 0 when the file is missing
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// NoteContent is a note with its frontmatter fields and Markdown body, as
+// printed by jot show --format
+type NoteContent struct {
+	Note
+	Fields  map[string]string `json:"fields"`  // Every frontmatter field as written
+	Content string            `json:"content"` // The body below the frontmatter
+}
+
+// RecentNote is a note with how recently and how often it was used
+type RecentNote struct {
+	Note
+	LastUsed time.Time `json:"last_used"`
+	Uses     int       `json:"uses"`
+	Score    float64   `json:"score"` // Frecency, higher for recent and frequent use
+}
+
+// Mention is an unlinked occurrence of a note's title in another note
+type Mention struct {
+	NoteID    string `json:"note_id"`
+	NoteTitle string `json:"note_title"`
+	Line      int    `json:"line"`
+	Text      string `json:"text"`
+}
+
+// LinkRewrite is a line changed by propagating a note rename to its links
+type LinkRewrite struct {
+	NoteID    string `json:"note_id"`
+	NoteTitle string `json:"note_title"`
+	Line      int    `json:"line"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+}
+
+// GraphFilter represents filtering options for the note graph
+type GraphFilter struct {
+	Tags        []string
+	Mode        string
+	From        string // Note to build a neighbourhood around
+	Depth       int    // Link hops from From
+	OrphansOnly bool
+	IncludeTags bool // Add nodes for tags shared by several notes
+}
+
+// Graph is the knowledge graph of notes, links and shared tags
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+}
+
+// GraphNode is a note or a tag in the graph
+type GraphNode struct {
+	ID     string   `json:"id"`
+	Type   string   `json:"type"` // "note" or "tag"
+	Label  string   `json:"label"`
+	Mode   string   `json:"mode,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	Orphan bool     `json:"orphan,omitempty"` // Note without links in or out
+}
+
+// GraphEdge connects two graph nodes
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"` // "link" or "tag"
+}
+
 // Config represents a configuration setting
 type Config struct {
 	Key       string    `db:"key" json:"key"`
@@ -43,23 +182,57 @@ type Config struct {
 // SearchResult represents a full-text search result
 type SearchResult struct {
 	Note
-	Rank      float64 `json:"rank"`       // Search relevance rank
-	Snippet   string  `json:"snippet"`    // Highlighted content snippet
-	MatchType string  `json:"match_type"` // "title", "content", "tags"
+	Rank      float64 `json:"rank"`              // Search relevance rank
+	Snippet   string  `json:"snippet"`           // Highlighted content snippet
+	MatchType string  `json:"match_type"`        // "title", "content", "tags"
+	Line      int     `json:"line,omitempty"`    // First matching line in the file
+	Heading   string  `json:"heading,omitempty"` // Heading enclosing that line
+}
+
+// CreateOptions holds the settings for creating a new note
+type CreateOptions struct {
+	Title    string
+	Tags     []string
+	Mode     string
+	Date     time.Time         // Note date, defaults to now
+	Template string            // Template name, defaults to the mode's template
+	Vars     map[string]string // Extra template variables (--var key=value)
+	Body     string            // Initial body when no template is given
+	TTL      string            // Expire after e.g. "7d", defaults to the mode's ttl; "never" keeps the note
+}
+
+// Template represents a note template on disk
+type Template struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // ListFilter represents filtering options for listing notes
 type ListFilter struct {
-	Tags      []string
-	Mode      string
-	Since     *time.Time
-	Until     *time.Time
-	Limit     int
-	Offset    int
-	SortBy    string // "created", "updated", "title"
-	SortOrder string // "asc", "desc"
+	Tags        []string
+	Mode        string
+	Since       *time.Time
+	Until       *time.Time
+	Limit       int
+	Offset      int
+	SortBy      string // "created", "updated", "title"
+	SortOrder   string // "asc", "desc"
+	Archived    ArchiveFilter
+	PinnedFirst bool       // Pinned notes before the others, each sorted by SortBy
+	ExpiresBy   *time.Time // Only notes set to expire by then
 }
 
+// ArchiveFilter selects notes by whether they are archived. The zero value
+// keeps every note, so lookups by ID or title still find archived notes.
+type ArchiveFilter int
+
+const (
+	IncludeArchived ArchiveFilter = iota
+	ExcludeArchived
+	OnlyArchived
+)
+
 // DefaultListFilter returns a filter with sensible defaults
 func DefaultListFilter() ListFilter {
 	return ListFilter{
@@ -79,3 +252,16 @@ type StatsResult struct {
 	WordCount     int            `json:"word_count"`
 	CreatedToday  int            `json:"created_today"`
 }
+
+// ModeSummary describes a mode from the registry and how many notes use it
+type ModeSummary struct {
+	Name           string   `json:"name"`
+	Color          string   `json:"color,omitempty"`
+	Directory      string   `json:"directory,omitempty"`
+	Editor         string   `json:"editor,omitempty"`
+	DefaultTags    []string `json:"default_tags,omitempty"`
+	RequiredFields []string `json:"required_fields,omitempty"`
+	TTL            string   `json:"ttl,omitempty"`
+	NoteCount      int      `json:"note_count"`
+	Registered     bool     `json:"registered"` // False for modes used by notes but missing from config
+}
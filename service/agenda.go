@@ -0,0 +1,167 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+// priorityRank orders tasks within a day, highest priority first
+var priorityRank = map[string]int{
+	"high":   0,
+	"medium": 1,
+	"":       2,
+	"low":    3,
+}
+
+// icsPriority maps task priorities to iCalendar PRIORITY values
+var icsPriority = map[string]int{
+	"high":   1,
+	"medium": 5,
+	"low":    9,
+}
+
+// Agenda returns the open tasks that are overdue or due within the given
+// number of days from today, grouped by due day
+func (s *NoteService) Agenda(today time.Time, days int) ([]*models.AgendaDay, error) {
+	tasks, err := s.taskRepo.List(models.TaskFilter{DueOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return groupAgenda(tasks, startOfDay(today), days), nil
+}
+
+// AgendaICS renders all open tasks with a due date as an iCalendar feed
+func (s *NoteService) AgendaICS(now time.Time) (string, error) {
+	tasks, err := s.taskRepo.List(models.TaskFilter{DueOnly: true})
+	if err != nil {
+		return "", err
+	}
+
+	sortTasks(tasks)
+	return renderICS(tasks, now), nil
+}
+
+// groupAgenda groups tasks by due day, oldest first. Overdue tasks are
+// always included; upcoming ones only up to days after today.
+func groupAgenda(tasks []*models.Task, today time.Time, days int) []*models.AgendaDay {
+	horizon := today.AddDate(0, 0, days)
+
+	groups := make(map[string]*models.AgendaDay)
+	for _, task := range tasks {
+		if task.Due == nil || task.Done || task.Due.After(horizon) {
+			continue
+		}
+
+		key := task.Due.Format(dayLayout)
+		group, ok := groups[key]
+		if !ok {
+			group = &models.AgendaDay{Day: startOfDay(*task.Due)}
+			groups[key] = group
+		}
+		group.Tasks = append(group.Tasks, task)
+	}
+
+	agenda := make([]*models.AgendaDay, 0, len(groups))
+	for _, group := range groups {
+		sortTasks(group.Tasks)
+		agenda = append(agenda, group)
+	}
+	sort.Slice(agenda, func(i, j int) bool {
+		return agenda[i].Day.Before(agenda[j].Day)
+	})
+
+	return agenda
+}
+
+// sortTasks orders tasks by due date, priority, note and line
+func sortTasks(tasks []*models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if a.Due != nil && b.Due != nil && !a.Due.Equal(*b.Due) {
+			return a.Due.Before(*b.Due)
+		}
+		if priorityRank[a.Priority] != priorityRank[b.Priority] {
+			return priorityRank[a.Priority] < priorityRank[b.Priority]
+		}
+		if a.NoteID != b.NoteID {
+			return a.NoteID < b.NoteID
+		}
+		return a.Line < b.Line
+	})
+}
+
+// renderICS renders tasks as all-day iCalendar events
+func renderICS(tasks []*models.Task, now time.Time) string {
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//jot//agenda//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:jot tasks",
+	)
+
+	for _, task := range tasks {
+		if task.Due == nil || task.Done {
+			continue
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+task.ID+"@jot",
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+task.Due.Format("20060102"),
+			"DTEND;VALUE=DATE:"+task.Due.AddDate(0, 0, 1).Format("20060102"),
+			"SUMMARY:"+escapeICSText(task.Text),
+			"DESCRIPTION:"+escapeICSText(fmt.Sprintf("%s (%s), line %d", task.NoteTitle, task.NoteID, task.Line)),
+		)
+		if priority, ok := icsPriority[task.Priority]; ok {
+			lines = append(lines, fmt.Sprintf("PRIORITY:%d", priority))
+		}
+		if task.Assignee != "" {
+			lines = append(lines, "X-JOT-ASSIGNEE:"+escapeICSText(task.Assignee))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// escapeICSText escapes a TEXT value as required by RFC 5545
+func escapeICSText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// foldICSLine splits content lines longer than 75 octets, without breaking
+// multi-byte characters
+func foldICSLine(line string) string {
+	const limit = 75
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+func agendaDate(value string) *time.Time {
+	day, _ := time.ParseInLocation(dayLayout, value, time.Local)
+	return &day
+}
+
+func TestGroupAgenda(t *testing.T) {
+	tasks := []*models.Task{
+		{ID: "a", Text: "later", Due: agendaDate("2025-11-20")},
+		{ID: "b", Text: "low today", Due: agendaDate("2025-11-03"), Priority: "low"},
+		{ID: "c", Text: "overdue", Due: agendaDate("2025-10-01")},
+		{ID: "d", Text: "high today", Due: agendaDate("2025-11-03"), Priority: "high"},
+		{ID: "e", Text: "no due date"},
+		{ID: "f", Text: "done", Due: agendaDate("2025-11-03"), Done: true},
+		{ID: "g", Text: "this week", Due: agendaDate("2025-11-05")},
+	}
+
+	agenda := groupAgenda(tasks, *agendaDate("2025-11-03"), 7)
+
+	var got []string
+	for _, day := range agenda {
+		var ids []string
+		for _, task := range day.Tasks {
+			ids = append(ids, task.ID)
+		}
+		got = append(got, day.Day.Format(dayLayout)+":"+strings.Join(ids, ","))
+	}
+
+	expected := []string{"2025-10-01:c", "2025-11-03:d,b", "2025-11-05:g"}
+	if strings.Join(got, " ") != strings.Join(expected, " ") {
+		t.Errorf("groupAgenda() = %v, expected %v", got, expected)
+	}
+}
+
+func TestRenderICS(t *testing.T) {
+	tasks := []*models.Task{
+		{
+			ID: "62491b7", NoteID: "b2b251b", NoteTitle: "Kafka, offsets; notes", Line: 12,
+			Text: "follow up with infra", Due: agendaDate("2025-11-03"), Priority: "high",
+		},
+	}
+	now := time.Date(2025, 11, 1, 9, 30, 0, 0, time.UTC)
+
+	ics := renderICS(tasks, now)
+
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:62491b7@jot\r\n",
+		"DTSTAMP:20251101T093000Z\r\n",
+		"DTSTART;VALUE=DATE:20251103\r\n",
+		"DTEND;VALUE=DATE:20251104\r\n",
+		"SUMMARY:follow up with infra\r\n",
+		`DESCRIPTION:Kafka\, offsets\; notes (b2b251b)\, line 12` + "\r\n",
+		"PRIORITY:1\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("ICS should contain %q, got:\n%s", expected, ics)
+		}
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := foldICSLine(line)
+
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("Folded line is %d octets, expected at most 75", len(part))
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Errorf("Unfolding should give back the original line")
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+// SetPinned pins a note so it is listed before the others, or unpins it.
+// The state lives in the note's frontmatter so it survives a re-index.
+func (s *NoteService) SetPinned(note *models.Note, pinned bool) (*models.Note, error) {
+	return s.editFrontmatter(note, func(content string) string {
+		return setFrontmatterFlag(content, "pinned", pinned)
+	})
+}
+
+// SetArchived archives a note, hiding it from list and search by default,
+// or brings it back. Like pinning, the state lives in the frontmatter.
+func (s *NoteService) SetArchived(note *models.Note, archived bool) (*models.Note, error) {
+	return s.editFrontmatter(note, func(content string) string {
+		content = setFrontmatterFlag(content, "archived", archived)
+		// An expired note brought back would be archived again by the next sync
+		if !archived && note.ExpiresAt != nil && !note.ExpiresAt.After(time.Now()) {
+			content = removeFrontmatterField(content, "expires")
+		}
+		return content
+	})
+}
+
+// setFrontmatterFlag writes "key: true" to the frontmatter, or removes the
+// field when the flag is off
+func setFrontmatterFlag(content, key string, on bool) string {
+	if on {
+		return setFrontmatterField(content, key, "true")
+	}
+	return removeFrontmatterField(content, key)
+}
+
+// editFrontmatter rewrites a note's file with edit and re-indexes it
+func (s *NoteService) editFrontmatter(note *models.Note, edit func(string) string) (*models.Note, error) {
+	content, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	updated := edit(string(content))
+	if updated == string(content) {
+		return note, nil
+	}
+	if err := os.WriteFile(note.FilePath, []byte(updated), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write note file: %w", err)
+	}
+
+	if err := s.syncNoteFromFile(note.FilePath, false); err != nil {
+		return nil, err
+	}
+	return s.noteRepo.GetByID(note.ID)
+}
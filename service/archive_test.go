@@ -0,0 +1,62 @@
+package service
+
+import "testing"
+
+func TestRemoveFrontmatterField(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "removes the field",
+			content:  "---\npinned: true\ntitle: Note\n---\n\nBody\n",
+			expected: "---\ntitle: Note\n---\n\nBody\n",
+		},
+		{
+			name:     "leaves the body alone",
+			content:  "---\ntitle: Note\n---\n\npinned: true\n",
+			expected: "---\ntitle: Note\n---\n\npinned: true\n",
+		},
+		{
+			name:     "no frontmatter",
+			content:  "pinned: true\n",
+			expected: "pinned: true\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := removeFrontmatterField(test.content, "pinned"); got != test.expected {
+				t.Errorf("removeFrontmatterField() =\n%q\nexpected\n%q", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestParseNoteFileStates(t *testing.T) {
+	service := &NoteService{}
+
+	tests := []struct {
+		frontmatter string
+		pinned      bool
+		archived    bool
+	}{
+		{"", false, false},
+		{"pinned: true\n", true, false},
+		{"archived: true\npinned: false\n", false, true},
+		{"archived: maybe\n", false, false},
+	}
+
+	for _, test := range tests {
+		content := "---\ntitle: Note\nmode: dev\n" + test.frontmatter + "---\n\nBody\n"
+		note, err := service.parseNoteFile("/notes/note.md", content)
+		if err != nil {
+			t.Fatalf("parseNoteFile() error: %v", err)
+		}
+		if note.Pinned != test.pinned || note.Archived != test.archived {
+			t.Errorf("parseNoteFile(%q) pinned = %v, archived = %v, expected %v, %v",
+				test.frontmatter, note.Pinned, note.Archived, test.pinned, test.archived)
+		}
+	}
+}
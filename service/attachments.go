@@ -0,0 +1,255 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+// markdownLinkPattern matches [text](target) and ![alt](target)
+var markdownLinkPattern = regexp.MustCompile(`!?\[([^\[\]]*)\]\(([^()\s]+)\)`)
+
+// assetNamePattern matches the content-hash names given to attached files
+var assetNamePattern = regexp.MustCompile(`^[0-9a-f]{16}(\.[a-z0-9]+)?$`)
+
+// imageExtensions are embedded with ![...] rather than linked
+var imageExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true,
+}
+
+// parseAttachments extracts the Markdown links in a note's body that point at
+// a file in the assets directory, skipping code. Relative targets are
+// resolved against the note's directory.
+func parseAttachments(noteDir, assetsDir, content string) []*models.Attachment {
+	var attachments []*models.Attachment
+	for _, line := range bodyLines(content) {
+		if line.InCode {
+			continue
+		}
+
+		text := inlineCodePattern.ReplaceAllString(line.Text, " ")
+		for _, match := range markdownLinkPattern.FindAllStringSubmatch(text, -1) {
+			target := filepath.FromSlash(match[2])
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(noteDir, target)
+			}
+			target = filepath.Clean(target)
+
+			asset := filepath.Base(target)
+			if filepath.Dir(target) != filepath.Clean(assetsDir) || !assetNamePattern.MatchString(asset) {
+				continue
+			}
+			attachments = append(attachments, &models.Attachment{
+				Line:  line.Number,
+				Name:  strings.TrimSpace(match[1]),
+				Asset: asset,
+			})
+		}
+	}
+	return attachments
+}
+
+// assetLink returns the Markdown link to an asset from a note, relative to
+// the note's directory so it renders in other Markdown viewers too
+func assetLink(noteDir, assetsDir, asset, name string) string {
+	target := filepath.Join(assetsDir, asset)
+	if rel, err := filepath.Rel(noteDir, target); err == nil {
+		target = rel
+	}
+
+	name = strings.NewReplacer("[", "", "]", "").Replace(name)
+	link := fmt.Sprintf("[%s](%s)", name, filepath.ToSlash(target))
+	if imageExtensions[filepath.Ext(asset)] {
+		link = "!" + link
+	}
+	return link
+}
+
+// storeAsset copies a file into the assets directory under the hash of its
+// content and returns the asset name. Identical files are stored once.
+func storeAsset(assetsDir, src string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	if info, err := in.Stat(); err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", src, err)
+	} else if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory", src)
+	}
+
+	tmp, err := os.CreateTemp(assetsDir, ".attach-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create asset file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), in); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write asset file: %w", err)
+	}
+
+	asset := hex.EncodeToString(hash.Sum(nil))[:16] + strings.ToLower(filepath.Ext(src))
+	if !assetNamePattern.MatchString(asset) {
+		// Extensions like ".tar~" would not be recognised when indexing
+		asset = asset[:16]
+	}
+
+	dest := filepath.Join(assetsDir, asset)
+	if _, err := os.Stat(dest); err == nil {
+		return asset, nil
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", fmt.Errorf("failed to store asset: %w", err)
+	}
+	return asset, nil
+}
+
+// AttachFiles copies files into the assets directory and appends links to
+// them at the end of a note
+func (s *NoteService) AttachFiles(identifier string, paths []string) (*models.Note, []*models.Attachment, error) {
+	note, err := s.ResolveNote(identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assetsDir := config.GetAssetsDir()
+	noteDir := filepath.Dir(note.FilePath)
+
+	var links []string
+	for _, path := range paths {
+		asset, err := storeAsset(assetsDir, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		links = append(links, assetLink(noteDir, assetsDir, asset, filepath.Base(path)))
+	}
+
+	content, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	text := strings.TrimRight(string(content), "\n") + "\n\n" + strings.Join(links, "\n") + "\n"
+	if err := os.WriteFile(note.FilePath, []byte(text), 0644); err != nil {
+		return nil, nil, fmt.Errorf("failed to write note file: %w", err)
+	}
+
+	if err := s.syncNoteFromFile(note.FilePath, false); err != nil {
+		return nil, nil, err
+	}
+
+	_, attachments, err := s.Attachments(note.ID)
+	return note, attachments, err
+}
+
+// Attachments returns a note and the assets it links to, with their sizes
+func (s *NoteService) Attachments(identifier string) (*models.Note, []*models.Attachment, error) {
+	note, err := s.ResolveNote(identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachments, err := s.assetRepo.ListForNote(note.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, attachment := range attachments {
+		attachment.Path = filepath.Join(config.GetAssetsDir(), attachment.Asset)
+		if info, err := os.Stat(attachment.Path); err == nil {
+			attachment.Size = info.Size()
+		} else {
+			attachment.Missing = true
+		}
+	}
+
+	return note, attachments, nil
+}
+
+// CollectGarbage removes the files in the assets directory that no note links
+// to, in the notes or in the trash, and returns their names and total size.
+// With dryRun nothing is removed.
+func (s *NoteService) CollectGarbage(dryRun bool) ([]string, int64, error) {
+	referenced, err := s.assetRepo.ReferencedAssets()
+	if err != nil {
+		return nil, 0, err
+	}
+	trashed, err := trashedAssets()
+	if err != nil {
+		return nil, 0, err
+	}
+	for asset := range trashed {
+		referenced[asset] = true
+	}
+
+	assetsDir := config.GetAssetsDir()
+	entries, err := os.ReadDir(assetsDir)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read assets directory: %w", err)
+	}
+
+	var removed []string
+	var size int64
+	for _, entry := range entries {
+		// Only touch files jot created, never anything else put in the directory
+		if entry.IsDir() || referenced[entry.Name()] || !assetNamePattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(filepath.Join(assetsDir, entry.Name())); err != nil {
+				return removed, size, fmt.Errorf("failed to remove asset: %w", err)
+			}
+		}
+		removed = append(removed, entry.Name())
+		size += info.Size()
+	}
+
+	return removed, size, nil
+}
+
+// trashedAssets returns the assets linked from notes in the trash. Those
+// notes are no longer indexed, and their relative links may not resolve from
+// the trash, so any link to a file named like an asset counts.
+func trashedAssets() (map[string]bool, error) {
+	assets := make(map[string]bool)
+	files, err := findNoteFiles(config.GetTrashDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return assets, nil
+		}
+		return nil, fmt.Errorf("failed to scan trash directory: %w", err)
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trashed note: %w", err)
+		}
+		for _, match := range markdownLinkPattern.FindAllStringSubmatch(string(content), -1) {
+			if asset := filepath.Base(filepath.FromSlash(match[2])); assetNamePattern.MatchString(asset) {
+				assets[asset] = true
+			}
+		}
+	}
+	return assets, nil
+}
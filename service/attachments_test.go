@@ -0,0 +1,150 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sk25469/jot/config"
+)
+
+func TestParseAttachments(t *testing.T) {
+	content := `---
+title: Outage
+---
+
+![graph.png](../assets/0123456789abcdef.png)
+[worker.log](/jot/assets/fedcba9876543210.log) and [docs](https://example.com)
+[elsewhere](../other/0123456789abcdef.png)
+[not ours](../assets/notes.txt)
+
+` + "```" + `
+[in code](../assets/aaaaaaaaaaaaaaaa.txt)
+` + "```" + `
+`
+
+	attachments := parseAttachments("/jot/notes", "/jot/assets", content)
+	if len(attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(attachments))
+	}
+
+	expected := []struct {
+		line  int
+		name  string
+		asset string
+	}{
+		{5, "graph.png", "0123456789abcdef.png"},
+		{6, "worker.log", "fedcba9876543210.log"},
+	}
+	for i, want := range expected {
+		got := attachments[i]
+		if got.Line != want.line || got.Name != want.name || got.Asset != want.asset {
+			t.Errorf("attachment %d = {%d %q %q}, expected {%d %q %q}",
+				i, got.Line, got.Name, got.Asset, want.line, want.name, want.asset)
+		}
+	}
+}
+
+func TestAssetLink(t *testing.T) {
+	testCases := []struct {
+		noteDir  string
+		asset    string
+		name     string
+		expected string
+	}{
+		{"/jot/notes", "0123456789abcdef.png", "screenshot.png", "![screenshot.png](../assets/0123456789abcdef.png)"},
+		{"/jot/notes/incidents", "0123456789abcdef.txt", "log [1].txt", "[log 1.txt](../../assets/0123456789abcdef.txt)"},
+	}
+
+	for _, tc := range testCases {
+		if got := assetLink(tc.noteDir, "/jot/assets", tc.asset, tc.name); got != tc.expected {
+			t.Errorf("assetLink(%q, %q) = %q, expected %q", tc.noteDir, tc.asset, got, tc.expected)
+		}
+
+		// A link we write must be recognised as an attachment when indexed
+		parsed := parseAttachments(tc.noteDir, "/jot/assets", assetLink(tc.noteDir, "/jot/assets", tc.asset, tc.name))
+		if len(parsed) != 1 || parsed[0].Asset != tc.asset {
+			t.Errorf("assetLink(%q, %q) is not parsed back as an attachment", tc.noteDir, tc.asset)
+		}
+	}
+}
+
+func TestStoreAsset(t *testing.T) {
+	dir := t.TempDir()
+	assetsDir := filepath.Join(dir, "assets")
+	if err := os.Mkdir(assetsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	first := filepath.Join(dir, "Screenshot.PNG")
+	second := filepath.Join(dir, "copy.png")
+	for _, path := range []string{first, second} {
+		if err := os.WriteFile(path, []byte("same bytes"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	asset, err := storeAsset(assetsDir, first)
+	if err != nil {
+		t.Fatalf("storeAsset() error: %v", err)
+	}
+	if !assetNamePattern.MatchString(asset) || filepath.Ext(asset) != ".png" {
+		t.Errorf("storeAsset() = %q, expected a content-hash name ending in .png", asset)
+	}
+
+	again, err := storeAsset(assetsDir, second)
+	if err != nil {
+		t.Fatalf("storeAsset() error: %v", err)
+	}
+	if again != asset {
+		t.Errorf("identical content stored as %q and %q", asset, again)
+	}
+
+	entries, _ := os.ReadDir(assetsDir)
+	if len(entries) != 1 {
+		t.Errorf("expected 1 file in the assets directory, got %d", len(entries))
+	}
+
+	if _, err := storeAsset(assetsDir, filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("storeAsset() expected an error for a missing file")
+	}
+}
+
+func TestCollectGarbageKeepsTrashedAssets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	assetsDir := config.GetAssetsDir()
+	if err := os.MkdirAll(assetsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, asset := range []string{"0123456789abcdef.png", "fedcba9876543210.log"} {
+		if err := os.WriteFile(filepath.Join(assetsDir, asset), []byte(asset), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := newTestService(t, map[string]string{
+		"outage.md": "---\ntitle: Outage\nmode: dev\n---\n\n![graph](" +
+			filepath.ToSlash(filepath.Join(assetsDir, "0123456789abcdef.png")) + ")\n",
+	})
+	note, err := s.ResolveNote("Outage")
+	if err != nil {
+		t.Fatalf("ResolveNote() error: %v", err)
+	}
+	if err := s.TrashNote(note); err != nil {
+		t.Fatalf("TrashNote() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(config.GetTrashDir(), "outage.md")); err != nil {
+		t.Errorf("expected the note in the trash: %v", err)
+	}
+
+	removed, _, err := s.CollectGarbage(false)
+	if err != nil {
+		t.Fatalf("CollectGarbage() error: %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "fedcba9876543210.log" {
+		t.Errorf("CollectGarbage() removed %v, expected only the unlinked asset", removed)
+	}
+	if _, err := os.Stat(filepath.Join(assetsDir, "0123456789abcdef.png")); err != nil {
+		t.Errorf("expected the trashed note's asset to be kept: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+const dayLayout = "2006-01-02"
+
+var dailyFileNamePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\.md$`)
+
+// OpenDailyNote opens the daily note for a day, creating it the first time
+func (s *NoteService) OpenDailyNote(day time.Time) (*models.Note, error) {
+	note, err := s.ensureDailyNote(day)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.openInEditor(note.FilePath, note.Mode); err != nil {
+		return nil, fmt.Errorf("failed to open editor: %w", err)
+	}
+	s.recordAccess(note.ID, AccessOpen)
+
+	return note, nil
+}
+
+// AppendToDailyNote adds a timestamped bullet to a day's note without opening the editor
+func (s *NoteService) AppendToDailyNote(day time.Time, text string) (*models.Note, error) {
+	note, err := s.ensureDailyNote(day)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read daily note: %w", err)
+	}
+
+	entry := fmt.Sprintf("- %s %s\n", time.Now().Format("15:04"), strings.TrimSpace(text))
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		entry = "\n" + entry
+	}
+
+	f, err := os.OpenFile(note.FilePath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open daily note: %w", err)
+	}
+	if _, err := f.WriteString(entry); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to append to daily note: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to append to daily note: %w", err)
+	}
+
+	// Re-index the note with the new entry
+	if err := s.syncNoteFromFile(note.FilePath, false); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// AdjacentDailyDay returns the nearest day before (direction < 0) or after
+// (direction > 0) the given day that has a daily note
+func (s *NoteService) AdjacentDailyDay(day time.Time, direction int) (time.Time, error) {
+	days, err := s.dailyNoteDays()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	target := day.Format(dayLayout)
+	if direction < 0 {
+		for i := len(days) - 1; i >= 0; i-- {
+			if days[i].Format(dayLayout) < target {
+				return days[i], nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("no daily note before %s", target)
+	}
+
+	for _, d := range days {
+		if d.Format(dayLayout) > target {
+			return d, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no daily note after %s", target)
+}
+
+// ensureDailyNote returns the daily note for a day, creating it from the daily template if needed
+func (s *NoteService) ensureDailyNote(day time.Time) (*models.Note, error) {
+	note, err := s.noteRepo.GetByID(dailyNoteID(day))
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if note != nil {
+		return note, nil
+	}
+
+	// Use the daily template only if it exists, otherwise fall back to the mode's template
+	dailyCfg := config.AppConfig.Daily
+	templateName := ""
+	if dailyCfg.Template != "" {
+		if path, err := templatePath(dailyCfg.Template); err == nil {
+			if _, err := os.Stat(path); err == nil {
+				templateName = dailyCfg.Template
+			}
+		}
+	}
+
+	return s.createNote(models.CreateOptions{
+		Title:    day.Format(dayLayout),
+		Mode:     dailyCfg.Mode,
+		Date:     time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC),
+		Template: templateName,
+	}, dailyFileName(day))
+}
+
+// dailyNoteDays returns the days that have a daily note, oldest first
+func (s *NoteService) dailyNoteDays() ([]time.Time, error) {
+	notes, err := s.noteRepo.List(models.ListFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily notes: %w", err)
+	}
+
+	var days []time.Time
+	for _, note := range notes {
+		if !dailyFileNamePattern.MatchString(note.FileName) {
+			continue
+		}
+		if day, ok := parseDay(strings.TrimSuffix(note.FileName, ".md")); ok {
+			days = append(days, day)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	return days, nil
+}
+
+// ParseDay parses a YYYY-MM-DD date in local time
+func ParseDay(value string) (time.Time, error) {
+	day, ok := parseDay(value)
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return day, nil
+}
+
+func parseDay(value string) (time.Time, bool) {
+	day, err := time.ParseInLocation(dayLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func dailyFileName(day time.Time) string {
+	return day.Format(dayLayout) + ".md"
+}
+
+func dailyNoteID(day time.Time) string {
+	return generateShortID(dailyFileName(day))
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDay(t *testing.T) {
+	day, err := ParseDay("2025-03-14")
+	if err != nil {
+		t.Fatalf("ParseDay() unexpected error: %v", err)
+	}
+	if day.Year() != 2025 || day.Month() != time.March || day.Day() != 14 {
+		t.Errorf("ParseDay() = %v, expected 2025-03-14", day)
+	}
+
+	for _, value := range []string{"", "2025-3-14", "14/03/2025", "f4f1c39", "2025-02-30"} {
+		if _, err := ParseDay(value); err == nil {
+			t.Errorf("ParseDay(%q) should fail", value)
+		}
+	}
+}
+
+func TestDailyNoteNaming(t *testing.T) {
+	day := time.Date(2025, 3, 14, 18, 45, 0, 0, time.Local)
+
+	if name := dailyFileName(day); name != "2025-03-14.md" {
+		t.Errorf("dailyFileName() = %q, expected %q", name, "2025-03-14.md")
+	}
+
+	if !dailyFileNamePattern.MatchString(dailyFileName(day)) {
+		t.Errorf("Daily file names should match the daily pattern")
+	}
+	if dailyFileNamePattern.MatchString("2025-03-14T10-00-00Z-standup.md") {
+		t.Errorf("Regular note file names should not match the daily pattern")
+	}
+
+	// The ID only depends on the day, so any time of day resolves to the same note
+	later := time.Date(2025, 3, 14, 23, 59, 0, 0, time.Local)
+	if dailyNoteID(day) != dailyNoteID(later) {
+		t.Errorf("Daily note IDs should be stable within a day")
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/sk25469/jot/config"
+)
+
+// editorLineArgs maps an editor's executable name to the arguments that open
+// a file at a line. {file} and {line} are replaced after splitting on spaces,
+// so paths containing spaces stay a single argument.
+var editorLineArgs = map[string]string{
+	"vi":            "+{line} {file}",
+	"vim":           "+{line} {file}",
+	"nvim":          "+{line} {file}",
+	"gvim":          "+{line} {file}",
+	"mvim":          "+{line} {file}",
+	"emacs":         "+{line} {file}",
+	"emacsclient":   "+{line} {file}",
+	"nano":          "+{line} {file}",
+	"micro":         "+{line} {file}",
+	"kak":           "+{line} {file}",
+	"joe":           "+{line} {file}",
+	"gedit":         "+{line} {file}",
+	"code":          "-g {file}:{line}",
+	"code-insiders": "-g {file}:{line}",
+	"codium":        "-g {file}:{line}",
+	"cursor":        "-g {file}:{line}",
+	"hx":            "{file}:{line}",
+	"helix":         "{file}:{line}",
+	"subl":          "{file}:{line}",
+	"zed":           "{file}:{line}",
+	"mate":          "-l {line} {file}",
+	"idea":          "--line {line} {file}",
+	"goland":        "--line {line} {file}",
+}
+
+// editorWaitFlags lists, for GUI editors that return immediately, the flag
+// that makes them wait for the file to be closed followed by its aliases.
+// Without it notes would be re-indexed before they were edited.
+var editorWaitFlags = map[string][]string{
+	"code":          {"--wait", "-w"},
+	"code-insiders": {"--wait", "-w"},
+	"codium":        {"--wait", "-w"},
+	"cursor":        {"--wait", "-w"},
+	"subl":          {"--wait", "-w"},
+	"zed":           {"--wait", "-w"},
+	"atom":          {"--wait", "-w"},
+	"mate":          {"-w", "--wait"},
+	"idea":          {"--wait"},
+	"goland":        {"--wait"},
+	"gvim":          {"-f", "--nofork"},
+	"mvim":          {"-f", "--nofork"},
+}
+
+// editorName returns the lower-case executable name of an editor program
+func editorName(program string) string {
+	return strings.ToLower(strings.TrimSuffix(filepath.Base(program), ".exe"))
+}
+
+// editorCommand splits an editor command line like "code --wait" or
+// "emacsclient -t" into the program and its arguments, adding the wait flag
+// GUI editors need when it is missing
+func editorCommand(command string) (string, []string, error) {
+	parts, err := shellquote.Split(command)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid editor command %q: %w", command, err)
+	}
+	if len(parts) == 0 {
+		return "", nil, fmt.Errorf("no editor configured")
+	}
+
+	program, args := parts[0], parts[1:]
+	if flags, ok := editorWaitFlags[editorName(program)]; ok && !hasAnyArg(args, flags) {
+		args = append(args, flags[0])
+	}
+	return program, args, nil
+}
+
+func hasAnyArg(args, wanted []string) bool {
+	for _, arg := range args {
+		for _, w := range wanted {
+			if arg == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// editorArgs returns the arguments that open a file in an editor, placing the
+// cursor on a line when line > 0 and the editor's syntax is known. Templates
+// in the editor_args config override the built-in ones.
+func editorArgs(editor, filePath string, line int) []string {
+	if line <= 0 {
+		return []string{filePath}
+	}
+
+	name := editorName(editor)
+	template, ok := config.AppConfig.EditorArgs[name]
+	if !ok {
+		template, ok = editorLineArgs[name]
+	}
+	if !ok {
+		// Unknown editor: opening the file is better than passing arguments it may reject
+		return []string{filePath}
+	}
+
+	var args []string
+	for _, field := range strings.Fields(template) {
+		field = strings.ReplaceAll(field, "{line}", strconv.Itoa(line))
+		args = append(args, strings.ReplaceAll(field, "{file}", filePath))
+	}
+	return args
+}
+
+func (s *NoteService) openInEditor(filePath, mode string) error {
+	return s.openInEditorAt(filePath, mode, 0)
+}
+
+// openInEditorAt opens a file with the cursor on a line, when line > 0
+func (s *NoteService) openInEditorAt(filePath, mode string, line int) error {
+	program, args, err := editorCommand(config.EditorCommand(mode))
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(program, append(args, editorArgs(program, filePath, line)...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
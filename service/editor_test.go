@@ -0,0 +1,98 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sk25469/jot/config"
+)
+
+func TestEditorArgs(t *testing.T) {
+	original := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = original })
+	config.AppConfig.EditorArgs = map[string]string{
+		"myedit": "--goto {line} {file}",
+		"nano":   "{file} +{line}",
+	}
+
+	file := "/notes/my note.md"
+	testCases := []struct {
+		editor   string
+		line     int
+		expected []string
+	}{
+		{"vim", 0, []string{file}},
+		{"vim", 12, []string{"+12", file}},
+		{"/usr/bin/nvim", 12, []string{"+12", file}},
+		{"emacs", 3, []string{"+3", file}},
+		{"code", 12, []string{"-g", file + ":12"}},
+		{"hx", 7, []string{file + ":7"}},
+		{"Code.exe", 5, []string{"-g", file + ":5"}},
+		{"myedit", 4, []string{"--goto", "4", file}},
+		{"nano", 9, []string{file, "+9"}},
+		{"unknown-editor", 9, []string{file}},
+	}
+
+	for _, tc := range testCases {
+		got := editorArgs(tc.editor, file, tc.line)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("editorArgs(%q, %d) = %q, expected %q", tc.editor, tc.line, got, tc.expected)
+		}
+	}
+}
+
+func TestLocateTask(t *testing.T) {
+	content := "---\ntitle: Tasks\n---\n\n- [ ] first\n- [ ] second\n"
+	tasks := parseTasks("n1", content)
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	// The task moved down two lines since it was indexed
+	moved := "---\ntitle: Tasks\n---\n\nintro\n\n- [ ] first\n- [ ] second\n"
+	current, err := locateTask(tasks[1], moved)
+	if err != nil {
+		t.Fatalf("locateTask() error: %v", err)
+	}
+	if current.Line != 8 {
+		t.Errorf("locateTask() line = %d, expected 8", current.Line)
+	}
+
+	if _, err := locateTask(tasks[1], "- [ ] first\n"); err == nil {
+		t.Error("locateTask() expected an error for a removed task")
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	testCases := []struct {
+		command  string
+		program  string
+		expected []string
+	}{
+		{"vim", "vim", []string{}},
+		{"emacsclient -t", "emacsclient", []string{"-t"}},
+		{"code", "code", []string{"--wait"}},
+		{"code --wait", "code", []string{"--wait"}},
+		{"code -w --new-window", "code", []string{"-w", "--new-window"}},
+		{"/usr/local/bin/subl", "/usr/local/bin/subl", []string{"--wait"}},
+		{"gvim", "gvim", []string{"-f"}},
+		{`"/Applications/My Editor/bin/ed" --flag 'a b'`, "/Applications/My Editor/bin/ed", []string{"--flag", "a b"}},
+	}
+
+	for _, tc := range testCases {
+		program, args, err := editorCommand(tc.command)
+		if err != nil {
+			t.Errorf("editorCommand(%q) error: %v", tc.command, err)
+			continue
+		}
+		if program != tc.program || !reflect.DeepEqual(args, tc.expected) {
+			t.Errorf("editorCommand(%q) = %q %q, expected %q %q", tc.command, program, args, tc.program, tc.expected)
+		}
+	}
+
+	for _, command := range []string{"", "   ", `code "unterminated`} {
+		if _, _, err := editorCommand(command); err == nil {
+			t.Errorf("editorCommand(%q) expected an error", command)
+		}
+	}
+}
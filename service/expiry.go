@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+// ExpiryActions are what sync can do with a note once it expires
+var ExpiryActions = []string{"archive", "trash"}
+
+// ParseTTL parses a note lifetime: a number of weeks ("2w") or days ("7d"),
+// or a Go duration such as "12h". An empty value, "0" or "never" is no TTL.
+func ParseTTL(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "0", "never":
+		return 0, nil
+	}
+
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	if unit, ok := units[value[len(value)-1:]]; ok {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n > 0 {
+			return time.Duration(n) * unit, nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid TTL %q (use e.g. 12h, 7d or 2w)", value)
+}
+
+// noteExpiry returns when a note created at createdAt expires: after the
+// given TTL, or the mode's ttl when none is given. It is nil for no TTL.
+func noteExpiry(ttl string, modeCfg config.ModeConfig, createdAt time.Time) (*time.Time, error) {
+	if ttl == "" {
+		ttl = modeCfg.TTL
+	}
+	d, err := ParseTTL(ttl)
+	if err != nil || d == 0 {
+		return nil, err
+	}
+	expires := createdAt.Add(d).UTC().Truncate(time.Second)
+	return &expires, nil
+}
+
+// parseExpiry reads the frontmatter expires field, an RFC 3339 timestamp or
+// a date meaning the start of that day
+func parseExpiry(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.UTC()
+		return &t
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		t = t.UTC()
+		return &t
+	}
+	return nil
+}
+
+// ExpireNotes archives or trashes, per the expiry action, every note whose
+// TTL has run out and returns them
+func (s *NoteService) ExpireNotes() ([]*models.Note, error) {
+	action := config.AppConfig.Expiry.Action
+	if action == "" {
+		action = "archive"
+	}
+	if !containsString(ExpiryActions, action) {
+		return nil, fmt.Errorf("unknown expiry action %q (available: %s)", action, strings.Join(ExpiryActions, ", "))
+	}
+
+	now := time.Now().UTC()
+	expired, err := s.noteRepo.List(models.ListFilter{Archived: models.ExcludeArchived, ExpiresBy: &now})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, note := range expired {
+		if action == "trash" {
+			err = s.TrashNote(note)
+		} else {
+			_, err = s.SetArchived(note, true)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to expire %s: %w", note.ID, err)
+		}
+	}
+	return expired, nil
+}
+
+// ExpiringNotes returns the notes that expire within the configured warning
+// window, soonest first
+func (s *NoteService) ExpiringNotes() ([]*models.Note, error) {
+	within, err := ParseTTL(config.AppConfig.Expiry.WarnWithin)
+	if err != nil || within == 0 {
+		return nil, err
+	}
+
+	by := time.Now().UTC().Add(within)
+	notes, err := s.noteRepo.List(models.ListFilter{Archived: models.ExcludeArchived, ExpiresBy: &by})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].ExpiresAt.Before(*notes[j].ExpiresAt)
+	})
+	return notes, nil
+}
+
+// TrashNote moves a note's file to the trash directory, at the same path it
+// had under the notes directory, and removes it from the index. The assets it
+// links to are kept by gc, so moving the file back restores the note.
+func (s *NoteService) TrashNote(note *models.Note) error {
+	rel, err := filepath.Rel(config.GetNotesDir(), note.FilePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = note.FileName
+	}
+	target := filepath.Join(config.GetTrashDir(), rel)
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	if err := os.Rename(note.FilePath, target); err != nil {
+		return fmt.Errorf("failed to move note to trash: %w", err)
+	}
+	return s.unindexNote(note)
+}
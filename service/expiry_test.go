@@ -0,0 +1,104 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"never", 0, false},
+		{"0", 0, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"2W", 14 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"d", 0, true},
+		{"3x", 0, true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseTTL(test.value)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseTTL(%q) error = %v, wantErr %v", test.value, err, test.wantErr)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("ParseTTL(%q) = %v, expected %v", test.value, got, test.expected)
+		}
+	}
+}
+
+func TestNoteExpiry(t *testing.T) {
+	created := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	scratch := config.ModeConfig{TTL: "7d"}
+
+	tests := []struct {
+		name     string
+		ttl      string
+		mode     config.ModeConfig
+		expected string
+	}{
+		{"no ttl", "", config.ModeConfig{}, ""},
+		{"mode default", "", scratch, "2025-03-21T09:30:00Z"},
+		{"flag wins over mode", "1d", scratch, "2025-03-15T09:30:00Z"},
+		{"never keeps the note", "never", scratch, ""},
+	}
+
+	for _, test := range tests {
+		expires, err := noteExpiry(test.ttl, test.mode, created)
+		if err != nil {
+			t.Errorf("%s: noteExpiry() error: %v", test.name, err)
+			continue
+		}
+		got := ""
+		if expires != nil {
+			got = expires.Format(time.RFC3339)
+		}
+		if got != test.expected {
+			t.Errorf("%s: noteExpiry() = %q, expected %q", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	if got := parseExpiry("2025-03-21T09:30:00Z"); got == nil || !got.Equal(time.Date(2025, 3, 21, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("parseExpiry(timestamp) = %v", got)
+	}
+	if got := parseExpiry("2025-03-21"); got == nil || !got.Equal(time.Date(2025, 3, 21, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("parseExpiry(date) = %v", got)
+	}
+	if got := parseExpiry("next week"); got != nil {
+		t.Errorf("parseExpiry(garbage) = %v, expected nil", got)
+	}
+}
+
+func TestGenerateNoteContentExpires(t *testing.T) {
+	service := &NoteService{}
+	expires := time.Date(2025, 3, 21, 9, 30, 0, 0, time.UTC)
+	note := &models.Note{
+		Title:     "Scratch",
+		Mode:      "dev",
+		CreatedAt: time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		ExpiresAt: &expires,
+	}
+
+	content := service.generateNoteContent(note)
+	if !strings.Contains(content, "\nexpires: 2025-03-21T09:30:00Z\n") {
+		t.Errorf("generateNoteContent() has no expires field:\n%s", content)
+	}
+	if got := parseExpiry(frontmatterFields(content)["expires"]); got == nil || !got.Equal(expires) {
+		t.Errorf("expires field parsed back as %v, expected %v", got, expires)
+	}
+}
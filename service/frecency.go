@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sk25469/jot/database"
+	"github.com/sk25469/jot/models"
+)
+
+// Actions recorded in the access log
+const (
+	AccessOpen = "open"
+	AccessShow = "show"
+)
+
+// frecencyBuckets weigh each use of a note by its age, like browser history:
+// a use this week counts ten times one from last year
+var frecencyBuckets = []struct {
+	age    time.Duration
+	weight float64
+}{
+	{4 * 24 * time.Hour, 100},
+	{14 * 24 * time.Hour, 70},
+	{31 * 24 * time.Hour, 50},
+	{90 * 24 * time.Hour, 30},
+}
+
+const frecencyOldWeight = 10
+
+// accessHistoryLimit is how many uses of each note the access log keeps; a
+// use beyond the last hundred adds little to a score
+const accessHistoryLimit = 100
+
+// frecency totals the access log per note as of now, highest score first. A
+// limit of 0 returns every note that was used.
+func (s *NoteService) frecency(now time.Time, limit int) ([]*models.RecentNote, error) {
+	buckets := make([]database.FrecencyBucket, len(frecencyBuckets))
+	for i, bucket := range frecencyBuckets {
+		buckets[i] = database.FrecencyBucket{Since: now.Add(-bucket.age), Weight: bucket.weight}
+	}
+	return s.accessRepo.Frecency(buckets, frecencyOldWeight, limit)
+}
+
+// recordAccess logs a use of a note. A failure only warns, since the note
+// itself was opened fine.
+func (s *NoteService) recordAccess(noteID, action string) {
+	err := s.accessRepo.Record(noteID, action, time.Now())
+	if err == nil {
+		err = s.accessRepo.Trim(noteID, accessHistoryLimit)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// RecentNotes returns the notes used most recently and most often, highest
+// frecency first. A limit of 0 returns them all.
+func (s *NoteService) RecentNotes(limit int) ([]*models.RecentNote, error) {
+	recent, err := s.frecency(time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, summary := range recent {
+		note, err := s.noteRepo.GetByID(summary.ID)
+		if err != nil {
+			return nil, err
+		}
+		if note != nil {
+			summary.Note = *note
+		}
+	}
+	return recent, nil
+}
+
+// LastNote returns the note used most recently, or nil when none was
+func (s *NoteService) LastNote() (*models.Note, error) {
+	id, err := s.accessRepo.Last()
+	if err != nil || id == "" {
+		return nil, err
+	}
+	return s.noteRepo.GetByID(id)
+}
+
+// rankCandidates orders the candidates of an ambiguous identifier by
+// frecency. A partial title resolves to the top candidate when it was used
+// more than the others; otherwise the identifier stays ambiguous.
+func (s *NoteService) rankCandidates(ambiguous *AmbiguousNoteError) (*models.Note, error) {
+	recent, err := s.frecency(time.Now(), 0)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[string]float64, len(recent))
+	for _, summary := range recent {
+		scores[summary.ID] = summary.Score
+	}
+	score := func(note *models.Note) float64 {
+		return scores[note.ID]
+	}
+
+	candidates := ambiguous.Candidates
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return score(candidates[i]) > score(candidates[j])
+	})
+
+	if !ambiguous.byID && score(candidates[0]) > score(candidates[1]) {
+		return candidates[0], nil
+	}
+	return nil, ambiguous
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrecency(t *testing.T) {
+	s := newTestService(t, map[string]string{
+		"a.md": "---\ntitle: Daily reflection\nmode: dev\n---\n",
+		"b.md": "---\ntitle: Kafka offsets\nmode: dev\n---\n",
+		"c.md": "---\ntitle: Offset reset broke\nmode: dev\n---\n",
+		"d.md": "---\ntitle: Never opened\nmode: dev\n---\n",
+	})
+	ids := make(map[string]string)
+	for _, title := range []string{"Daily reflection", "Kafka offsets", "Offset reset broke"} {
+		note, err := s.ResolveNote(title)
+		if err != nil {
+			t.Fatalf("ResolveNote(%q) error: %v", title, err)
+		}
+		ids[title] = note.ID
+	}
+
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	accesses := []struct {
+		title string
+		at    time.Time
+	}{
+		{"Daily reflection", now.Add(-time.Hour)},
+		{"Kafka offsets", now.Add(-2 * day)},
+		{"Kafka offsets", now.Add(-20 * day)},
+		{"Offset reset broke", now.Add(-400 * day)},
+		{"Offset reset broke", now.Add(-60 * day)},
+		{"Offset reset broke", now.Add(-5 * day)},
+	}
+	for _, access := range accesses {
+		if err := s.accessRepo.Record(ids[access.title], AccessOpen, access.at); err != nil {
+			t.Fatalf("Record() error: %v", err)
+		}
+	}
+
+	recent, err := s.frecency(now, 0)
+	if err != nil {
+		t.Fatalf("frecency() error: %v", err)
+	}
+
+	// Weights: 100 within 4 days, 70 within 14, 50 within 31, 30 within 90
+	// and 10 for older uses
+	expected := []struct {
+		title    string
+		uses     int
+		score    float64
+		lastUsed time.Time
+	}{
+		{"Kafka offsets", 2, 150, now.Add(-2 * day)},
+		{"Offset reset broke", 3, 110, now.Add(-5 * day)},
+		{"Daily reflection", 1, 100, now.Add(-time.Hour)},
+	}
+	if len(recent) != len(expected) {
+		t.Fatalf("frecency() returned %d notes, expected %d", len(recent), len(expected))
+	}
+	for i, want := range expected {
+		got := recent[i]
+		if got.ID != ids[want.title] || got.Uses != want.uses || got.Score != want.score || !got.LastUsed.Equal(want.lastUsed) {
+			t.Errorf("frecency()[%d] = %s: %d uses, score %v, last used %v; expected %s: %d, %v, %v",
+				i, got.ID, got.Uses, got.Score, got.LastUsed, ids[want.title], want.uses, want.score, want.lastUsed)
+		}
+	}
+
+	if limited, err := s.frecency(now, 1); err != nil || len(limited) != 1 || limited[0].ID != ids["Kafka offsets"] {
+		t.Errorf("frecency() with a limit of 1 = %v, %v; expected only Kafka offsets", limited, err)
+	}
+}
+
+func TestRecordAccessTrimsHistory(t *testing.T) {
+	s := newTestService(t, map[string]string{"a.md": "---\ntitle: Daily reflection\nmode: dev\n---\n"})
+	note, err := s.ResolveNote("Daily reflection")
+	if err != nil {
+		t.Fatalf("ResolveNote() error: %v", err)
+	}
+
+	for i := 0; i < accessHistoryLimit+5; i++ {
+		s.recordAccess(note.ID, AccessShow)
+	}
+
+	recent, err := s.frecency(time.Now(), 0)
+	if err != nil {
+		t.Fatalf("frecency() error: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Uses != accessHistoryLimit {
+		t.Errorf("frecency() = %v, expected %d uses kept", recent, accessHistoryLimit)
+	}
+}
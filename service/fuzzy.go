@@ -0,0 +1,150 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sk25469/jot/models"
+)
+
+// Scores for fuzzy matching, tuned like fzf: every matched character counts,
+// runs of consecutive characters and word starts count extra, gaps cost
+const (
+	fuzzyMatchScore       = 16
+	fuzzyConsecutiveBonus = 8
+	fuzzyBoundaryBonus    = 10
+	fuzzyMaxGapPenalty    = 8
+)
+
+// fuzzyMatch scores how well a query matches text as a subsequence. ok is
+// false when the characters of the query don't appear in order. Matching
+// ignores case unless the query has upper-case letters.
+func fuzzyMatch(query, text string) (score int, ok bool) {
+	q := []rune(query)
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	t := []rune(text)
+	if !hasUpper(query) {
+		for i, r := range t {
+			t[i] = unicode.ToLower(r)
+		}
+	}
+
+	// The forward pass finds where the earliest match ends, the backward
+	// pass from there the latest start, giving the tightest match
+	end, qi := -1, 0
+	for i, r := range t {
+		if r == q[qi] {
+			qi++
+			if qi == len(q) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, false
+	}
+
+	start, qi := end, len(q)-1
+	for i := end; i >= 0; i-- {
+		if t[i] == q[qi] {
+			qi--
+			if qi < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	prev := -1
+	qi = 0
+	for i := start; i <= end && qi < len(q); i++ {
+		if t[i] != q[qi] {
+			continue
+		}
+		score += fuzzyMatchScore
+		if prev >= 0 {
+			if i == prev+1 {
+				score += fuzzyConsecutiveBonus
+			} else {
+				score -= min(i-prev-1, fuzzyMaxGapPenalty)
+			}
+		}
+		if i == 0 || isWordBoundary(t[i-1]) {
+			score += fuzzyBoundaryBonus
+		}
+		prev = i
+		qi++
+	}
+
+	return score, true
+}
+
+func hasUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWordBoundary(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// FilterNotes returns the notes whose ID, title and #tags fuzzy match every
+// space-separated term of a query, best matches first. Notes that score the
+// same keep their order, and an empty query returns all notes.
+func FilterNotes(query string, notes []*models.Note) []*models.Note {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return notes
+	}
+
+	type scoredNote struct {
+		note  *models.Note
+		score int
+	}
+
+	var matches []scoredNote
+	for _, note := range notes {
+		text := noteSearchText(note)
+
+		total, matched := 0, true
+		for _, term := range terms {
+			score, ok := fuzzyMatch(term, text)
+			if !ok {
+				matched = false
+				break
+			}
+			total += score
+		}
+		if matched {
+			matches = append(matches, scoredNote{note, total})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	filtered := make([]*models.Note, len(matches))
+	for i, match := range matches {
+		filtered[i] = match.note
+	}
+	return filtered
+}
+
+// noteSearchText is the text of a note that fuzzy queries match against
+func noteSearchText(note *models.Note) string {
+	parts := []string{note.ID, note.Title}
+	for _, tag := range note.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ")
+}
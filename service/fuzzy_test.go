@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sk25469/jot/models"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		ok    bool
+	}{
+		{"", "anything", true},
+		{"kfk", "Kafka offsets", true},
+		{"KO", "Kafka offsets", false}, // Upper case makes the match case-sensitive
+		{"KO", "Kafka Offsets", true},
+		{"ofk", "Kafka offsets", false},
+		{"ü", "Über notes", true},
+	}
+
+	for _, test := range tests {
+		if _, ok := fuzzyMatch(test.query, test.text); ok != test.ok {
+			t.Errorf("fuzzyMatch(%q, %q) ok = %v, expected %v", test.query, test.text, ok, test.ok)
+		}
+	}
+
+	// Consecutive characters at a word start beat scattered ones
+	tight, _ := fuzzyMatch("off", "kafka offsets")
+	loose, _ := fuzzyMatch("off", "old fluffy fox")
+	if tight <= loose {
+		t.Errorf("fuzzyMatch scored a word-start run %d, not above scattered characters %d", tight, loose)
+	}
+}
+
+func TestFilterNotes(t *testing.T) {
+	notes := []*models.Note{
+		{ID: "a1", Title: "Daily reflection"},
+		{ID: "b2", Title: "Kafka offsets deep dive", Tags: []string{"kafka"}},
+		{ID: "c3", Title: "Offset reset broke", Tags: []string{"incident"}},
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"a1", "b2", "c3"}},
+		{"offset", []string{"b2", "c3"}},
+		{"#inc", []string{"c3"}},
+		{"offset #kafka", []string{"b2"}},
+		{"b2", []string{"b2"}},
+		{"zzz", nil},
+	}
+
+	for _, test := range tests {
+		filtered := FilterNotes(test.query, notes)
+		var ids []string
+		for _, note := range filtered {
+			ids = append(ids, note.ID)
+		}
+		if len(ids) != len(test.expected) {
+			t.Errorf("FilterNotes(%q) = %v, expected %v", test.query, ids, test.expected)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != test.expected[i] {
+				t.Errorf("FilterNotes(%q) = %v, expected %v", test.query, ids, test.expected)
+				break
+			}
+		}
+	}
+}
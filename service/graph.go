@@ -0,0 +1,255 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+// GraphFormats lists the supported graph output formats
+var GraphFormats = []string{"dot", "mermaid", "json"}
+
+// BuildGraph returns the graph of notes, their [[links]] and shared tags
+func (s *NoteService) BuildGraph(filter models.GraphFilter) (*models.Graph, error) {
+	notes, err := s.noteRepo.List(models.ListFilter{SortBy: "title", SortOrder: "asc"})
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.ListResolved()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filter.Tags) > 0 {
+		filter.Tags = NormalizeTags(filter.Tags)
+	}
+	filter.Mode = strings.ToLower(filter.Mode)
+
+	if filter.From != "" {
+		from, err := matchNote(filter.From, notes)
+		if err != nil {
+			return nil, err
+		}
+		filter.From = from.ID
+	}
+
+	return buildGraph(notes, links, filter), nil
+}
+
+// buildGraph selects the notes matching the filter and connects them
+func buildGraph(notes []*models.Note, links []*models.Link, filter models.GraphFilter) *models.Graph {
+	// Undirected adjacency over links, ignoring self-links
+	neighbours := make(map[string]map[string]bool)
+	connect := func(a, b string) {
+		if neighbours[a] == nil {
+			neighbours[a] = make(map[string]bool)
+		}
+		neighbours[a][b] = true
+	}
+	for _, link := range links {
+		if link.SourceID != link.TargetID {
+			connect(link.SourceID, link.TargetID)
+			connect(link.TargetID, link.SourceID)
+		}
+	}
+
+	selected := make(map[string]bool)
+	for _, note := range notes {
+		if filter.Mode != "" && note.Mode != filter.Mode {
+			continue
+		}
+		if len(filter.Tags) > 0 && !hasAnyTag(note, filter.Tags) {
+			continue
+		}
+		if filter.OrphansOnly && len(neighbours[note.ID]) > 0 {
+			continue
+		}
+		selected[note.ID] = true
+	}
+
+	// Keep the neighbourhood of a note, walking links between selected notes
+	if filter.From != "" {
+		depth := filter.Depth
+		if depth < 1 {
+			depth = 1
+		}
+
+		reached := map[string]bool{filter.From: true}
+		frontier := []string{filter.From}
+		for hop := 0; hop < depth; hop++ {
+			var next []string
+			for _, id := range frontier {
+				for neighbour := range neighbours[id] {
+					if selected[neighbour] && !reached[neighbour] {
+						reached[neighbour] = true
+						next = append(next, neighbour)
+					}
+				}
+			}
+			frontier = next
+		}
+		selected = reached
+	}
+
+	graph := &models.Graph{Nodes: []*models.GraphNode{}, Edges: []*models.GraphEdge{}}
+
+	tagNotes := make(map[string][]string)
+	for _, note := range notes {
+		if !selected[note.ID] {
+			continue
+		}
+		graph.Nodes = append(graph.Nodes, &models.GraphNode{
+			ID:     note.ID,
+			Type:   "note",
+			Label:  note.Title,
+			Mode:   note.Mode,
+			Tags:   note.Tags,
+			Orphan: len(neighbours[note.ID]) == 0,
+		})
+		for _, tag := range note.Tags {
+			tagNotes[tag] = append(tagNotes[tag], note.ID)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, link := range links {
+		key := link.SourceID + ">" + link.TargetID
+		if link.SourceID == link.TargetID || seen[key] || !selected[link.SourceID] || !selected[link.TargetID] {
+			continue
+		}
+		seen[key] = true
+		graph.Edges = append(graph.Edges, &models.GraphEdge{Source: link.SourceID, Target: link.TargetID, Type: "link"})
+	}
+
+	if filter.IncludeTags {
+		var tags []string
+		for tag, ids := range tagNotes {
+			if len(ids) > 1 {
+				tags = append(tags, tag)
+			}
+		}
+		sort.Strings(tags)
+
+		for _, tag := range tags {
+			tagID := "#" + tag
+			graph.Nodes = append(graph.Nodes, &models.GraphNode{ID: tagID, Type: "tag", Label: tagID})
+			for _, noteID := range tagNotes[tag] {
+				graph.Edges = append(graph.Edges, &models.GraphEdge{Source: noteID, Target: tagID, Type: "tag"})
+			}
+		}
+	}
+
+	return graph
+}
+
+func hasAnyTag(note *models.Note, tags []string) bool {
+	for _, tag := range tags {
+		if containsString(note.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderGraph renders a graph as Graphviz DOT, a Mermaid flowchart or JSON
+func RenderGraph(graph *models.Graph, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "dot":
+		return renderDOT(graph), nil
+	case "mermaid":
+		return renderMermaid(graph), nil
+	case "json":
+		data, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode graph: %w", err)
+		}
+		return string(data) + "\n", nil
+	}
+	return "", fmt.Errorf("unknown graph format %q, expected one of: %s", format, strings.Join(GraphFormats, ", "))
+}
+
+func renderDOT(graph *models.Graph) string {
+	var b strings.Builder
+	b.WriteString("digraph jot {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"#F8F8F2\"];\n")
+
+	for _, node := range graph.Nodes {
+		attrs := []string{"label=" + dotQuote(node.Label)}
+		if node.Type == "tag" {
+			attrs = append(attrs, "shape=ellipse", `fillcolor="#44475A"`, `fontcolor="#F8F8F2"`)
+		} else {
+			if mode, ok := config.GetMode(node.Mode); ok && mode.Color != "" {
+				attrs = append(attrs, "color="+dotQuote(mode.Color), "penwidth=2")
+			}
+			if node.Orphan {
+				attrs = append(attrs, `style="rounded,filled,dashed"`)
+			}
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range graph.Edges {
+		style := ""
+		if edge.Type == "tag" {
+			style = " [style=dashed, arrowhead=none]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", dotQuote(edge.Source), dotQuote(edge.Target), style)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+func renderMermaid(graph *models.Graph) string {
+	// Mermaid IDs must be simple identifiers, so number the nodes
+	ids := make(map[string]string, len(graph.Nodes))
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, node := range graph.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node.ID] = id
+
+		label := mermaidQuote(node.Label)
+		if node.Type == "tag" {
+			fmt.Fprintf(&b, "  %s((%s))\n", id, label)
+		} else {
+			fmt.Fprintf(&b, "  %s[%s]\n", id, label)
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		arrow := "-->"
+		if edge.Type == "tag" {
+			arrow = "-.-"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[edge.Source], arrow, ids[edge.Target])
+	}
+
+	var orphans []string
+	for _, node := range graph.Nodes {
+		if node.Orphan {
+			orphans = append(orphans, ids[node.ID])
+		}
+	}
+	if len(orphans) > 0 {
+		b.WriteString("  classDef orphan stroke-dasharray: 5 5\n")
+		fmt.Fprintf(&b, "  class %s orphan\n", strings.Join(orphans, ","))
+	}
+
+	return b.String()
+}
+
+func mermaidQuote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sk25469/jot/models"
+)
+
+func graphFixture() ([]*models.Note, []*models.Link) {
+	notes := []*models.Note{
+		{ID: "a", Title: "Kafka overview", Mode: "dev", Tags: []string{"kafka"}},
+		{ID: "b", Title: "Offset reset", Mode: "dev", Tags: []string{"kafka", "incident"}},
+		{ID: "c", Title: "Consumer lag", Mode: "dev", Tags: []string{"kafka"}},
+		{ID: "d", Title: "Standup", Mode: "journal"},
+		{ID: "e", Title: "Loose thought", Mode: "journal"},
+	}
+	links := []*models.Link{
+		{SourceID: "a", TargetID: "b"},
+		{SourceID: "a", TargetID: "b"}, // Linked twice
+		{SourceID: "b", TargetID: "c"},
+		{SourceID: "d", TargetID: "a"},
+		{SourceID: "e", TargetID: "e"}, // Self-links don't count
+	}
+	return notes, links
+}
+
+func graphNodeIDs(graph *models.Graph) string {
+	var ids []string
+	for _, node := range graph.Nodes {
+		ids = append(ids, node.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestBuildGraph(t *testing.T) {
+	notes, links := graphFixture()
+
+	graph := buildGraph(notes, links, models.GraphFilter{IncludeTags: true})
+	if got := graphNodeIDs(graph); got != "a,b,c,d,e,#kafka" {
+		t.Errorf("Nodes = %s, expected a,b,c,d,e,#kafka", got)
+	}
+
+	var linkEdges, tagEdges int
+	for _, edge := range graph.Edges {
+		if edge.Type == "link" {
+			linkEdges++
+		} else {
+			tagEdges++
+		}
+	}
+	if linkEdges != 3 || tagEdges != 3 {
+		t.Errorf("Got %d link and %d tag edges, expected 3 and 3", linkEdges, tagEdges)
+	}
+
+	for _, node := range graph.Nodes {
+		if node.Orphan != (node.ID == "e") {
+			t.Errorf("Node %s orphan = %t", node.ID, node.Orphan)
+		}
+	}
+}
+
+func TestBuildGraphFilters(t *testing.T) {
+	notes, links := graphFixture()
+
+	tests := []struct {
+		name     string
+		filter   models.GraphFilter
+		expected string
+	}{
+		{"mode", models.GraphFilter{Mode: "journal"}, "d,e"},
+		{"tag", models.GraphFilter{Tags: []string{"incident"}}, "b"},
+		{"orphans", models.GraphFilter{OrphansOnly: true}, "e"},
+		{"neighbourhood", models.GraphFilter{From: "c", Depth: 1}, "b,c"},
+		{"deeper neighbourhood", models.GraphFilter{From: "c", Depth: 2}, "a,b,c"},
+		{"neighbourhood within mode", models.GraphFilter{From: "a", Depth: 3, Mode: "dev"}, "a,b,c"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := graphNodeIDs(buildGraph(notes, links, test.filter)); got != test.expected {
+				t.Errorf("Nodes = %s, expected %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestRenderGraph(t *testing.T) {
+	graph := &models.Graph{
+		Nodes: []*models.GraphNode{
+			{ID: "a", Type: "note", Label: `The "Kafka" note`},
+			{ID: "b", Type: "note", Label: "Loose", Orphan: true},
+			{ID: "#kafka", Type: "tag", Label: "#kafka"},
+		},
+		Edges: []*models.GraphEdge{
+			{Source: "a", Target: "#kafka", Type: "tag"},
+		},
+	}
+
+	dot, err := RenderGraph(graph, "dot")
+	if err != nil {
+		t.Fatalf("RenderGraph(dot) failed: %v", err)
+	}
+	for _, expected := range []string{`"a" [label="The \"Kafka\" note"`, `"a" -> "#kafka" [style=dashed`} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("DOT output should contain %q, got:\n%s", expected, dot)
+		}
+	}
+
+	mermaid, _ := RenderGraph(graph, "mermaid")
+	for _, expected := range []string{`n0["The #quot;Kafka#quot; note"]`, `n2(("#kafka"))`, "n0 -.- n2", "class n1 orphan"} {
+		if !strings.Contains(mermaid, expected) {
+			t.Errorf("Mermaid output should contain %q, got:\n%s", expected, mermaid)
+		}
+	}
+
+	if _, err := RenderGraph(graph, "svg"); err == nil {
+		t.Errorf("RenderGraph should reject unknown formats")
+	}
+}
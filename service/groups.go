@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sk25469/jot/models"
+)
+
+// GroupFields are the values accepted by jot list --group-by
+var GroupFields = []string{"mode", "tag", "month", "week", "notebook"}
+
+const (
+	untaggedGroup = "(untagged)"
+	rootNotebook  = "(root)"
+)
+
+// GroupNotes splits notes into sections by mode, tag, month, week or notebook,
+// keeping the order of the notes within each section. A note with several
+// tags appears under each of them. Months and weeks are sorted newest first,
+// the other sections by name.
+func GroupNotes(notes []*models.Note, by, notesDir string) ([]*models.NoteGroup, error) {
+	var keys func(*models.Note) []string
+	newestFirst := false
+
+	switch by {
+	case "mode":
+		keys = func(n *models.Note) []string { return []string{n.Mode} }
+	case "tag":
+		keys = func(n *models.Note) []string {
+			if len(n.Tags) == 0 {
+				return []string{untaggedGroup}
+			}
+			return n.Tags
+		}
+	case "month":
+		keys = func(n *models.Note) []string { return []string{n.CreatedAt.Format("2006-01")} }
+		newestFirst = true
+	case "week":
+		keys = func(n *models.Note) []string {
+			year, week := n.CreatedAt.ISOWeek()
+			return []string{fmt.Sprintf("%d-W%02d", year, week)}
+		}
+		newestFirst = true
+	case "notebook":
+		keys = func(n *models.Note) []string { return []string{notebook(n.FilePath, notesDir)} }
+	default:
+		return nil, fmt.Errorf("cannot group by %q (available: %s)", by, strings.Join(GroupFields, ", "))
+	}
+
+	index := make(map[string]*models.NoteGroup)
+	var groups []*models.NoteGroup
+	for _, note := range notes {
+		for _, key := range keys(note) {
+			group, ok := index[key]
+			if !ok {
+				group = &models.NoteGroup{Name: key}
+				index[key] = group
+				groups = append(groups, group)
+			}
+			group.Notes = append(group.Notes, note)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Name, groups[j].Name
+		// Placeholder sections go last
+		if (a == untaggedGroup) != (b == untaggedGroup) {
+			return b == untaggedGroup
+		}
+		if newestFirst {
+			return a > b
+		}
+		return a < b
+	})
+
+	return groups, nil
+}
+
+// notebook returns the folder a note lives in under the notes directory,
+// e.g. "incidents" for a mode with its own directory
+func notebook(filePath, notesDir string) string {
+	rel, err := filepath.Rel(notesDir, filepath.Dir(filePath))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return rootNotebook
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+func TestGroupNotes(t *testing.T) {
+	notes := []*models.Note{
+		{ID: "a", Mode: "dev", Tags: []string{"kafka", "incident"}, CreatedAt: time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC), FilePath: "/jot/notes/a.md"},
+		{ID: "b", Mode: "journal", CreatedAt: time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC), FilePath: "/jot/notes/b.md"},
+		{ID: "c", Mode: "dev", Tags: []string{"kafka"}, CreatedAt: time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC), FilePath: "/jot/notes/incidents/c.md"},
+		{ID: "d", Mode: "incident", Tags: []string{"incident"}, CreatedAt: time.Date(2024, 12, 30, 9, 0, 0, 0, time.UTC), FilePath: "/jot/notes/incidents/2024/d.md"},
+	}
+
+	testCases := []struct {
+		by       string
+		expected map[string]string // Group name -> note IDs, in group order
+		order    []string
+	}{
+		{"mode", map[string]string{"dev": "ac", "incident": "d", "journal": "b"}, []string{"dev", "incident", "journal"}},
+		{"tag", map[string]string{"incident": "ad", "kafka": "ac", "(untagged)": "b"}, []string{"incident", "kafka", "(untagged)"}},
+		{"month", map[string]string{"2025-03": "ab", "2025-02": "c", "2024-12": "d"}, []string{"2025-03", "2025-02", "2024-12"}},
+		{"week", map[string]string{"2025-W11": "a", "2025-W10": "b", "2025-W09": "c", "2025-W01": "d"}, []string{"2025-W11", "2025-W10", "2025-W09", "2025-W01"}},
+		{"notebook", map[string]string{"(root)": "ab", "incidents": "c", "incidents/2024": "d"}, []string{"(root)", "incidents", "incidents/2024"}},
+	}
+
+	for _, tc := range testCases {
+		groups, err := GroupNotes(notes, tc.by, "/jot/notes")
+		if err != nil {
+			t.Errorf("GroupNotes(%s) error: %v", tc.by, err)
+			continue
+		}
+
+		if len(groups) != len(tc.order) {
+			t.Errorf("GroupNotes(%s) returned %d groups, expected %d", tc.by, len(groups), len(tc.order))
+			continue
+		}
+		for i, group := range groups {
+			if group.Name != tc.order[i] {
+				t.Errorf("GroupNotes(%s) group %d = %q, expected %q", tc.by, i, group.Name, tc.order[i])
+			}
+			ids := ""
+			for _, note := range group.Notes {
+				ids += note.ID
+			}
+			if ids != tc.expected[group.Name] {
+				t.Errorf("GroupNotes(%s) group %q = %q, expected %q", tc.by, group.Name, ids, tc.expected[group.Name])
+			}
+		}
+	}
+
+	if _, err := GroupNotes(notes, "author", "/jot/notes"); err == nil {
+		t.Error("GroupNotes(author) expected an error")
+	}
+}
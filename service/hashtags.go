@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	hashtagPattern    = regexp.MustCompile(`(^|[\s(\[,;])#([\p{L}\p{N}_][\p{L}\p{N}_/-]*)`)
+	inlineCodePattern = regexp.MustCompile("`[^`]*`")
+	urlPattern        = regexp.MustCompile(`(?:[a-zA-Z][a-zA-Z0-9+.-]*://|www\.)\S+|\]\([^)]*\)`)
+	letterPattern     = regexp.MustCompile(`\p{L}`)
+	modeTokenPattern  = regexp.MustCompile(`^@(\p{L}[\p{L}\p{N}_-]*)$`)
+)
+
+// extractHashtags returns the #tags in a note body, skipping code and URLs
+func extractHashtags(content string) []string {
+	var tags []string
+	for _, line := range bodyLines(content) {
+		if line.InCode {
+			continue
+		}
+		tags = append(tags, findHashtags(line.Text)...)
+	}
+	return tags
+}
+
+// extractTitleHashtags removes #tags from a title and returns them separately
+func extractTitleHashtags(title string) (string, []string) {
+	tags := findHashtags(title)
+	if len(tags) == 0 {
+		return title, nil
+	}
+
+	cleaned := hashtagPattern.ReplaceAllStringFunc(title, func(match string) string {
+		idx := strings.Index(match, "#")
+		if !letterPattern.MatchString(match[idx+1:]) {
+			return match
+		}
+		return match[:idx]
+	})
+	return strings.Join(strings.Fields(cleaned), " "), tags
+}
+
+// extractTitleMode removes an @mode word from a title and returns the mode
+// separately, or "" when there is none. Only whole words count, so an email
+// address stays in the title.
+func extractTitleMode(title string) (string, string, error) {
+	var words, modes []string
+	for _, word := range strings.Fields(title) {
+		if match := modeTokenPattern.FindStringSubmatch(word); match != nil {
+			modes = append(modes, match[1])
+			continue
+		}
+		words = append(words, word)
+	}
+
+	switch len(modes) {
+	case 0:
+		return title, "", nil
+	case 1:
+		return strings.Join(words, " "), modes[0], nil
+	}
+	return "", "", fmt.Errorf("title names several modes: @%s", strings.Join(modes, ", @"))
+}
+
+// findHashtags returns the #tags in a single line of text
+func findHashtags(text string) []string {
+	text = inlineCodePattern.ReplaceAllString(text, " ")
+	text = urlPattern.ReplaceAllString(text, " ")
+
+	var tags []string
+	for _, match := range hashtagPattern.FindAllStringSubmatch(text, -1) {
+		tag := match[2]
+		// Skip issue references like #123
+		if !letterPattern.MatchString(tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "plain body",
+			content:  "offset reset broke #kafka and #incident",
+			expected: []string{"kafka", "incident"},
+		},
+		{
+			name: "frontmatter is skipped",
+			content: `---
+title: Test #notatag
+tags: [kafka]
+---
+
+Body with #real`,
+			expected: []string{"real"},
+		},
+		{
+			name:     "fenced code blocks are skipped",
+			content:  "before #one\n```bash\necho #two\n```\nafter #three",
+			expected: []string{"one", "three"},
+		},
+		{
+			name:     "inline code and URLs are skipped",
+			content:  "see `#code` at https://example.com/#anchor and [docs](http://x.io/#frag) #kept",
+			expected: []string{"kept"},
+		},
+		{
+			name:     "headings and issue numbers are not tags",
+			content:  "# Heading\nfixed in #123 #v2",
+			expected: []string{"v2"},
+		},
+		{
+			name:     "tags inside words are ignored",
+			content:  "email me at a#b or C#",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := extractHashtags(tc.content)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("extractHashtags() = %v, expected %v", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestExtractTitleHashtags(t *testing.T) {
+	title, tags := extractTitleHashtags("offset reset broke #kafka #incident")
+
+	if title != "offset reset broke" {
+		t.Errorf("Title = %q, expected %q", title, "offset reset broke")
+	}
+
+	expected := []string{"kafka", "incident"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Tags = %v, expected %v", tags, expected)
+	}
+
+	title, tags = extractTitleHashtags("Ticket #42")
+	if title != "Ticket #42" || len(tags) != 0 {
+		t.Errorf("Issue numbers should stay in the title, got %q %v", title, tags)
+	}
+}
+
+func TestExtractTitleMode(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+		mode     string
+		wantErr  bool
+	}{
+		{"quick thought @journal", "quick thought", "journal", false},
+		{"@meeting sync with infra", "sync with infra", "meeting", false},
+		{"mail bob@example.com", "mail bob@example.com", "", false},
+		{"no mode here", "no mode here", "", false},
+		{"@dev and @journal", "", "", true},
+	}
+
+	for _, test := range tests {
+		title, mode, err := extractTitleMode(test.title)
+		if (err != nil) != test.wantErr {
+			t.Errorf("extractTitleMode(%q) error = %v, wantErr %v", test.title, err, test.wantErr)
+			continue
+		}
+		if title != test.expected || mode != test.mode {
+			t.Errorf("extractTitleMode(%q) = %q, %q, expected %q, %q", test.title, title, mode, test.expected, test.mode)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/sk25469/jot/models"
+)
+
+var headingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.+?)(?:[ \t]+#+)?[ \t]*$`)
+
+// parseHeadings extracts the ATX (#-style) headings from a note's body, skipping code
+func parseHeadings(noteID, content string) []*models.Heading {
+	var headings []*models.Heading
+	for _, line := range bodyLines(content) {
+		if line.InCode {
+			continue
+		}
+
+		match := headingPattern.FindStringSubmatch(strings.TrimRight(line.Text, "\r"))
+		if match == nil {
+			continue
+		}
+
+		text := strings.TrimSpace(match[2])
+		headings = append(headings, &models.Heading{
+			NoteID: noteID,
+			Line:   line.Number,
+			Level:  len(match[1]),
+			Text:   text,
+			Slug:   headingSlug(text),
+		})
+	}
+	return headings
+}
+
+// headingSlug turns heading text into a GitHub-style anchor: lower case,
+// punctuation dropped and spaces replaced by hyphens
+func headingSlug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// findHeading finds the heading an anchor refers to, by exact slug first and
+// then by slug prefix
+func findHeading(headings []*models.Heading, anchor string) *models.Heading {
+	slug := headingSlug(anchor)
+	if slug == "" {
+		return nil
+	}
+
+	for _, heading := range headings {
+		if heading.Slug == slug {
+			return heading
+		}
+	}
+	for _, heading := range headings {
+		if strings.HasPrefix(heading.Slug, slug) {
+			return heading
+		}
+	}
+	return nil
+}
+
+// enclosingHeading returns the last heading at or before a line
+func enclosingHeading(headings []*models.Heading, line int) *models.Heading {
+	var enclosing *models.Heading
+	for _, heading := range headings {
+		if heading.Line > line {
+			break
+		}
+		enclosing = heading
+	}
+	return enclosing
+}
+
+// TableOfContents returns a note and its headings
+func (s *NoteService) TableOfContents(identifier string) (*models.Note, []*models.Heading, error) {
+	note, err := s.ResolveNote(identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headings, err := s.headingRepo.ListForNote(note.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return note, headings, nil
+}
+
+// resolveAnchor returns the line of the heading an anchor refers to in a note
+func (s *NoteService) resolveAnchor(note *models.Note, anchor string) (int, error) {
+	headings, err := s.headingRepo.ListForNote(note.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	heading := findHeading(headings, anchor)
+	if heading == nil {
+		return 0, fmt.Errorf("heading #%s not found in %s (%s)", anchor, note.Title, note.ID)
+	}
+
+	return heading.Line, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestParseHeadings(t *testing.T) {
+	content := "---\ntitle: Runbook\n---\n\n" +
+		"# Kafka runbook\n" +
+		"#kafka is a tag, not a heading\n" +
+		"## Rollback plan ##\n" +
+		"```\n# not a heading\n```\n" +
+		"### Verify `lag` & offsets\n"
+
+	headings := parseHeadings("f4f1c39", content)
+
+	expected := []struct {
+		line  int
+		level int
+		text  string
+		slug  string
+	}{
+		{5, 1, "Kafka runbook", "kafka-runbook"},
+		{7, 2, "Rollback plan", "rollback-plan"},
+		{11, 3, "Verify `lag` & offsets", "verify-lag--offsets"},
+	}
+	if len(headings) != len(expected) {
+		t.Fatalf("parseHeadings() returned %d headings, expected %d", len(headings), len(expected))
+	}
+	for i, exp := range expected {
+		h := headings[i]
+		if h.Line != exp.line || h.Level != exp.level || h.Text != exp.text || h.Slug != exp.slug {
+			t.Errorf("Heading %d = %+v, expected %+v", i, *h, exp)
+		}
+	}
+}
+
+func TestFindHeading(t *testing.T) {
+	headings := parseHeadings("", "# Kafka runbook\n## Rollback plan\n## Rollback\n### Verify lag\n")
+
+	tests := []struct {
+		anchor   string
+		expected int
+	}{
+		{"rollback", 3},      // Exact slug wins over prefixes
+		{"Rollback plan", 2}, // Heading text works too
+		{"verify", 4},        // Prefix of a slug
+		{"missing", 0},
+	}
+
+	for _, test := range tests {
+		heading := findHeading(headings, test.anchor)
+		line := 0
+		if heading != nil {
+			line = heading.Line
+		}
+		if line != test.expected {
+			t.Errorf("findHeading(%q) found line %d, expected %d", test.anchor, line, test.expected)
+		}
+	}
+
+	if enclosing := enclosingHeading(headings, 3); enclosing == nil || enclosing.Text != "Rollback" {
+		t.Errorf("enclosingHeading(3) = %v, expected Rollback", enclosing)
+	}
+	if enclosing := enclosingHeading(parseHeadings("", "Intro\n# Later\n"), 1); enclosing != nil {
+		t.Errorf("Lines before the first heading should have no enclosing heading")
+	}
+}
+
+func TestFirstMatchLine(t *testing.T) {
+	content := "---\ntitle: Kafka\n---\n\nIntro\nReset the consumer group.\n"
+
+	if line := firstMatchLine(content, searchTerms(`"Consumer" AND group*`)); line != 6 {
+		t.Errorf("firstMatchLine() = %d, expected 6", line)
+	}
+	if line := firstMatchLine(content, []string{"kafka"}); line != 0 {
+		t.Errorf("Frontmatter should not count as a match, got line %d", line)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sk25469/jot/models"
+)
+
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]|]+)(?:\|([^\[\]]*))?\]\]`)
+
+// minMentionLength keeps very short titles from matching everywhere
+const minMentionLength = 3
+
+// parseLinks extracts the [[wiki links]] from a note's body, skipping code.
+// A [[title#heading]] link keeps the heading's slug as its anchor.
+func parseLinks(content string) []*models.Link {
+	var links []*models.Link
+	for _, line := range bodyLines(content) {
+		if line.InCode {
+			continue
+		}
+
+		text := inlineCodePattern.ReplaceAllString(line.Text, " ")
+		for _, match := range wikiLinkPattern.FindAllStringSubmatch(text, -1) {
+			target, anchor, _ := strings.Cut(match[1], "#")
+			target = strings.TrimSpace(target)
+			if target == "" {
+				continue
+			}
+			links = append(links, &models.Link{
+				Line:   line.Number,
+				Target: target,
+				Alias:  strings.TrimSpace(match[2]),
+				Anchor: headingSlug(anchor),
+			})
+		}
+	}
+	return links
+}
+
+// resolveLinks points every link at the note its target resolves to, using
+// the same rules as opening a note. Unresolvable targets are marked broken.
+func (s *NoteService) resolveLinks() error {
+	targets, err := s.linkRepo.Targets()
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	notes, err := s.noteRepo.List(models.ListFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list notes for link resolution: %w", err)
+	}
+
+	resolved := make(map[string]string, len(targets))
+	for _, target := range targets {
+		if note, err := matchNote(target, notes); err == nil {
+			resolved[target] = note.ID
+		} else {
+			resolved[target] = ""
+		}
+	}
+
+	return s.linkRepo.ResolveTargets(resolved)
+}
+
+// Links returns a note and the links it contains
+func (s *NoteService) Links(identifier string) (*models.Note, []*models.Link, error) {
+	note, err := s.ResolveNote(identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	links, err := s.linkRepo.ListOutgoing(note.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return note, links, nil
+}
+
+// BrokenLinks returns the links across all notes whose target does not resolve
+func (s *NoteService) BrokenLinks() ([]*models.Link, error) {
+	return s.linkRepo.ListBroken()
+}
+
+// Backlinks returns a note, the links pointing at it and unlinked mentions
+// of its title in notes that do not link to it yet
+func (s *NoteService) Backlinks(identifier string) (*models.Note, []*models.Link, []*models.Mention, error) {
+	note, err := s.ResolveNote(identifier)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	links, err := s.linkRepo.ListIncoming(note.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	linked := map[string]bool{note.ID: true}
+	for _, link := range links {
+		linked[link.SourceID] = true
+	}
+
+	notes, err := s.noteRepo.List(models.ListFilter{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list notes for mentions: %w", err)
+	}
+
+	var mentions []*models.Mention
+	for _, other := range notes {
+		if linked[other.ID] {
+			continue
+		}
+
+		content, err := os.ReadFile(other.FilePath)
+		if err != nil {
+			continue
+		}
+
+		for _, mention := range findMentions(note.Title, string(content)) {
+			mention.NoteID = other.ID
+			mention.NoteTitle = other.Title
+			mentions = append(mentions, mention)
+		}
+	}
+
+	return note, links, mentions, nil
+}
+
+// findMentions returns the body lines that mention a title as a whole phrase
+// outside of code and existing links
+func findMentions(title, content string) []*models.Mention {
+	title = strings.TrimSpace(title)
+	if utf8.RuneCountInString(title) < minMentionLength {
+		return nil
+	}
+
+	pattern := regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}_])` + regexp.QuoteMeta(title) + `($|[^\p{L}\p{N}_])`)
+
+	var mentions []*models.Mention
+	for _, line := range bodyLines(content) {
+		if line.InCode {
+			continue
+		}
+
+		text := inlineCodePattern.ReplaceAllString(line.Text, " ")
+		text = wikiLinkPattern.ReplaceAllString(text, " ")
+		if pattern.MatchString(text) {
+			mentions = append(mentions, &models.Mention{
+				Line: line.Number,
+				Text: strings.TrimSpace(line.Text),
+			})
+		}
+	}
+	return mentions
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sk25469/jot/models"
+)
+
+func TestParseLinks(t *testing.T) {
+	content := "---\ntitle: Kafka incident\n---\n\n" +
+		"See [[Offset reset]] and [[f4f1c39|the design doc]].\n" +
+		"Code like `[[not a link]]` is skipped.\n" +
+		"```\n[[inside code]]\n```\n" +
+		"[[ ]] [[2025-03-14]]\n" +
+		"[[Runbook#Rollback plan|how to roll back]]\n"
+
+	links := parseLinks(content)
+
+	expected := []models.Link{
+		{Line: 5, Target: "Offset reset"},
+		{Line: 5, Target: "f4f1c39", Alias: "the design doc"},
+		{Line: 10, Target: "2025-03-14"},
+		{Line: 11, Target: "Runbook", Alias: "how to roll back", Anchor: "rollback-plan"},
+	}
+	if len(links) != len(expected) {
+		t.Fatalf("parseLinks() returned %d links, expected %d", len(links), len(expected))
+	}
+	for i, exp := range expected {
+		if *links[i] != exp {
+			t.Errorf("Link %d = %+v, expected %+v", i, *links[i], exp)
+		}
+	}
+}
+
+func TestMatchNote(t *testing.T) {
+	notes := []*models.Note{
+		{ID: "f4f1c39", Title: "Kafka offsets deep dive"},
+		{ID: "f4a0b12", Title: "Kafka"},
+		{ID: "5f3f8ed", Title: "Daily reflection"},
+	}
+
+	tests := []struct {
+		identifier string
+		expectedID string
+	}{
+		{"f4f1c39", "f4f1c39"},
+		{"5f3", "5f3f8ed"},
+		{"kafka", "f4a0b12"}, // Exact title wins over partial matches
+		{"offsets", "f4f1c39"},
+		{"nothing like it", ""},
+		{"f4", ""},  // Ambiguous
+		{"afk", ""}, // Ambiguous partial title
+	}
+
+	for _, test := range tests {
+		note, err := matchNote(test.identifier, notes)
+		if test.expectedID == "" {
+			if err == nil {
+				t.Errorf("matchNote(%q) should fail, got %s", test.identifier, note.ID)
+			}
+			var ambiguous *AmbiguousNoteError
+			if errors.As(err, &ambiguous) && len(ambiguous.Candidates) != 2 {
+				t.Errorf("matchNote(%q) offered %d candidates, expected 2", test.identifier, len(ambiguous.Candidates))
+			}
+			continue
+		}
+		if err != nil || note.ID != test.expectedID {
+			t.Errorf("matchNote(%q) = %v, %v; expected %s", test.identifier, note, err, test.expectedID)
+		}
+	}
+}
+
+func TestFindMentions(t *testing.T) {
+	content := "---\ntitle: Standup\n---\n\n" +
+		"Talked about offset reset with infra.\n" +
+		"Already linked: [[Offset reset]].\n" +
+		"The offset resetting script is unrelated.\n" +
+		"```\noffset reset\n```\n"
+
+	mentions := findMentions("Offset reset", content)
+	if len(mentions) != 1 {
+		t.Fatalf("findMentions() returned %d mentions, expected 1", len(mentions))
+	}
+	if mentions[0].Line != 5 || mentions[0].Text != "Talked about offset reset with infra." {
+		t.Errorf("Unexpected mention: %+v", mentions[0])
+	}
+
+	if len(findMentions("Go", "Go is great.\n")) != 0 {
+		t.Errorf("Very short titles should not produce mentions")
+	}
+}
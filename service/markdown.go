@@ -0,0 +1,139 @@
+package service
+
+import (
+	"strings"
+)
+
+// markdownLine is a line of a note body with its position in the file
+type markdownLine struct {
+	Number int    // 1-based line number in the file
+	Text   string // Raw line text
+	InCode bool   // Inside (or delimiting) a fenced code block
+}
+
+// splitFrontmatter returns the note body and the 0-based line index where it starts
+func splitFrontmatter(content string) (string, int) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return content, 0
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return strings.Join(lines[i+1:], "\n"), i + 1
+		}
+	}
+
+	// Unterminated frontmatter - treat the whole file as body
+	return content, 0
+}
+
+// bodyLines splits the note body into lines, marking fenced code blocks
+func bodyLines(content string) []markdownLine {
+	body, start := splitFrontmatter(content)
+
+	var lines []markdownLine
+	fence := ""
+	for i, text := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(text)
+		line := markdownLine{Number: start + i + 1, Text: text}
+
+		if fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			fence = trimmed[:3]
+			line.InCode = true
+		} else if fence != "" {
+			line.InCode = true
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// frontmatterFields returns the raw key/value pairs from the YAML frontmatter
+func frontmatterFields(content string) map[string]string {
+	fields := make(map[string]string)
+
+	_, start := splitFrontmatter(content)
+	if start == 0 {
+		return fields
+	}
+
+	lines := strings.Split(content, "\n")
+	for _, line := range lines[1 : start-1] {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), "\"")
+	}
+
+	return fields
+}
+
+// SplitNote separates a note file into its frontmatter fields and body
+func SplitNote(content string) (map[string]string, string) {
+	body, _ := splitFrontmatter(content)
+	return frontmatterFields(content), strings.TrimLeft(body, "\n")
+}
+
+// parseTagList parses a frontmatter tag list like "[kafka, debugging]"
+func parseTagList(value string) []string {
+	value = strings.Trim(strings.TrimSpace(value), "[]")
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// setFrontmatterTitle returns the content with its frontmatter title replaced,
+// adding a title field or frontmatter block if there is none
+func setFrontmatterTitle(content, title string) string {
+	return setFrontmatterField(content, "title", title)
+}
+
+// setFrontmatterField returns the content with a frontmatter field set,
+// adding the field at the top of the frontmatter, or a frontmatter block,
+// if there is none
+func setFrontmatterField(content, key, value string) string {
+	fieldLine := key + ": " + value
+
+	_, start := splitFrontmatter(content)
+	if start == 0 {
+		return "---\n" + fieldLine + "\n---\n\n" + content
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 1; i < start-1; i++ {
+		k, _, ok := strings.Cut(strings.TrimSpace(lines[i]), ":")
+		if ok && strings.TrimSpace(k) == key {
+			lines[i] = fieldLine
+			return strings.Join(lines, "\n")
+		}
+	}
+
+	lines = append(lines[:1], append([]string{fieldLine}, lines[1:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+// removeFrontmatterField returns the content without a frontmatter field,
+// unchanged if there is no such field
+func removeFrontmatterField(content, key string) string {
+	_, start := splitFrontmatter(content)
+	if start == 0 {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 1; i < start-1; i++ {
+		k, _, ok := strings.Cut(strings.TrimSpace(lines[i]), ":")
+		if ok && strings.TrimSpace(k) == key {
+			return strings.Join(append(lines[:i], lines[i+1:]...), "\n")
+		}
+	}
+	return content
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+// ListModes returns the registered modes and any unregistered modes still in use
+func (s *NoteService) ListModes() ([]*models.ModeSummary, error) {
+	counts, err := s.statsRepo.GetModeCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var modes []*models.ModeSummary
+	for _, name := range config.ModeNames() {
+		modeCfg, _ := config.GetMode(name)
+		modes = append(modes, &models.ModeSummary{
+			Name:           name,
+			Color:          modeCfg.Color,
+			Directory:      modeCfg.Directory,
+			Editor:         modeCfg.Editor,
+			DefaultTags:    modeCfg.DefaultTags,
+			RequiredFields: modeCfg.RequiredFields,
+			TTL:            modeCfg.TTL,
+			NoteCount:      counts[name],
+			Registered:     true,
+		})
+	}
+
+	// Notes created before the registry (or edited by hand) may use unknown modes
+	var unregistered []string
+	for name := range counts {
+		if _, ok := config.GetMode(name); !ok {
+			unregistered = append(unregistered, name)
+		}
+	}
+	sort.Strings(unregistered)
+
+	for _, name := range unregistered {
+		modes = append(modes, &models.ModeSummary{
+			Name:      name,
+			NoteCount: counts[name],
+		})
+	}
+
+	return modes, nil
+}
+
+// resolveMode validates a mode against the registry and returns its canonical name
+func resolveMode(mode string) (string, config.ModeConfig, error) {
+	if mode == "" {
+		mode = config.AppConfig.DefaultMode
+	}
+
+	name := strings.ToLower(strings.TrimSpace(mode))
+	if modeCfg, ok := config.GetMode(name); ok {
+		return name, modeCfg, nil
+	}
+
+	msg := fmt.Sprintf("unknown mode '%s'", mode)
+	if suggestion := closestMode(name); suggestion != "" {
+		msg += fmt.Sprintf(", did you mean '%s'?", suggestion)
+	}
+	return "", config.ModeConfig{}, fmt.Errorf("%s (available: %s)",
+		msg, strings.Join(config.ModeNames(), ", "))
+}
+
+// closestMode suggests a registered mode for a likely typo
+func closestMode(name string) string {
+	best := ""
+	bestDistance := 3 // Only suggest modes within two edits
+	for _, candidate := range config.ModeNames() {
+		if d := editDistance(name, candidate); d < bestDistance {
+			best = candidate
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// missingRequiredFields returns the required frontmatter fields a note leaves empty
+func missingRequiredFields(mode, content string) []string {
+	modeCfg, ok := config.GetMode(mode)
+	if !ok || len(modeCfg.RequiredFields) == 0 {
+		return nil
+	}
+
+	fields := frontmatterFields(content)
+	var missing []string
+	for _, field := range modeCfg.RequiredFields {
+		if strings.TrimSpace(fields[field]) == "" {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// editDistance computes the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+
+	return prev[len(rb)]
+}
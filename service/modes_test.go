@@ -0,0 +1,131 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+func withModes(t *testing.T, modes map[string]config.ModeConfig) {
+	t.Helper()
+	original := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = original })
+	config.AppConfig.DefaultMode = "dev"
+	config.AppConfig.Modes = modes
+}
+
+func TestResolveMode(t *testing.T) {
+	withModes(t, map[string]config.ModeConfig{
+		"dev":     {},
+		"journal": {DefaultTags: []string{"daily"}},
+	})
+
+	testCases := []struct {
+		input       string
+		expected    string
+		expectError string
+	}{
+		{"", "dev", ""},
+		{"journal", "journal", ""},
+		{"Journal", "journal", ""},
+		{"jurnal", "", "did you mean 'journal'?"},
+		{"recipes", "", "unknown mode 'recipes' (available: dev, journal)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			name, _, err := resolveMode(tc.input)
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Errorf("resolveMode(%q) error = %v, expected it to contain %q", tc.input, err, tc.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveMode(%q) unexpected error: %v", tc.input, err)
+			}
+			if name != tc.expected {
+				t.Errorf("resolveMode(%q) = %q, expected %q", tc.input, name, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMissingRequiredFields(t *testing.T) {
+	withModes(t, map[string]config.ModeConfig{
+		"incident": {RequiredFields: []string{"ticket", "severity"}},
+	})
+
+	content := `---
+title: DB down
+mode: incident
+ticket: INC-42
+severity:
+---
+`
+	missing := missingRequiredFields("incident", content)
+	if !reflect.DeepEqual(missing, []string{"severity"}) {
+		t.Errorf("missingRequiredFields() = %v, expected [severity]", missing)
+	}
+
+	if missing := missingRequiredFields("dev", content); missing != nil {
+		t.Errorf("Unregistered modes should not require fields, got %v", missing)
+	}
+}
+
+func TestGenerateNoteContentRequiredFields(t *testing.T) {
+	withModes(t, map[string]config.ModeConfig{
+		"incident": {RequiredFields: []string{"ticket"}},
+	})
+
+	service := &NoteService{}
+	content := service.generateNoteContent(&models.Note{Title: "DB down", Mode: "incident"})
+
+	if !strings.Contains(content, "ticket: \n---") {
+		t.Errorf("Content should contain a ticket placeholder, got: %s", content)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"journal", "journal", 0},
+		{"jurnal", "journal", 1},
+		{"dve", "dev", 2},
+		{"", "dev", 3},
+	}
+
+	for _, tc := range testCases {
+		if d := editDistance(tc.a, tc.b); d != tc.expected {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", tc.a, tc.b, d, tc.expected)
+		}
+	}
+}
+
+func TestCreateNoteTitleMode(t *testing.T) {
+	s := newTestService(t, nil)
+	config.AppConfig.Modes["journal"] = config.ModeConfig{}
+
+	note, err := s.createNote(models.CreateOptions{Title: "quick thought @journal #idea"}, "")
+	if err != nil {
+		t.Fatalf("createNote() error: %v", err)
+	}
+	if note.Mode != "journal" || note.Title != "quick thought" || !reflect.DeepEqual(note.Tags, []string{"idea"}) {
+		t.Errorf("createNote() = mode %q, title %q, tags %v, expected journal, \"quick thought\", [idea]", note.Mode, note.Title, note.Tags)
+	}
+
+	_, err = s.createNote(models.CreateOptions{Title: "quick thought @jurnal"}, "")
+	if err == nil || !strings.Contains(err.Error(), "did you mean 'journal'") {
+		t.Errorf("createNote() with @jurnal error = %v, expected a suggestion", err)
+	}
+
+	_, err = s.createNote(models.CreateOptions{Title: "standup @journal", Mode: "dev"}, "")
+	if err == nil {
+		t.Errorf("createNote() with @journal and mode dev returned no error")
+	}
+}
@@ -2,11 +2,14 @@ package service
 
 import (
 	"crypto/sha1"
+	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,32 +18,100 @@ import (
 	"github.com/sk25469/jot/models"
 )
 
+// indexVersion is bumped whenever sync starts extracting something new from
+// note files, so existing notes are re-indexed once
+const indexVersion = 9
+
 // NoteService handles business logic for notes
 type NoteService struct {
-	noteRepo  *database.NoteRepository
-	statsRepo *database.StatsRepository
+	noteRepo    *database.NoteRepository
+	statsRepo   *database.StatsRepository
+	configRepo  *database.ConfigRepository
+	taskRepo    *database.TaskRepository
+	linkRepo    *database.LinkRepository
+	headingRepo *database.HeadingRepository
+	assetRepo   *database.AttachmentRepository
+	accessRepo  *database.AccessRepository
 }
 
 // NewNoteService creates a new note service
 func NewNoteService(db *database.DB) *NoteService {
 	return &NoteService{
-		noteRepo:  database.NewNoteRepository(db),
-		statsRepo: database.NewStatsRepository(db),
+		noteRepo:    database.NewNoteRepository(db),
+		statsRepo:   database.NewStatsRepository(db),
+		configRepo:  database.NewConfigRepository(db),
+		taskRepo:    database.NewTaskRepository(db),
+		linkRepo:    database.NewLinkRepository(db),
+		headingRepo: database.NewHeadingRepository(db),
+		assetRepo:   database.NewAttachmentRepository(db),
+		accessRepo:  database.NewAccessRepository(db),
 	}
 }
 
-// CreateNote creates a new note with the given title and options
-func (s *NoteService) CreateNote(title string, tags []string, mode string) (*models.Note, error) {
-	if mode == "" {
-		mode = config.AppConfig.DefaultMode
+// CreateNote creates a new note with the given options and opens it in the editor
+func (s *NoteService) CreateNote(opts models.CreateOptions) (*models.Note, error) {
+	note, err := s.createNote(opts, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Open in editor
+	if err := s.openInEditor(note.FilePath, note.Mode); err != nil {
+		return nil, fmt.Errorf("failed to open editor: %w", err)
+	}
+	s.recordAccess(note.ID, AccessOpen)
+
+	return note, nil
+}
+
+// createNote writes a new note file and indexes it. An empty filename
+// generates the usual timestamp-based name.
+func (s *NoteService) createNote(opts models.CreateOptions, filename string) (*models.Note, error) {
+	// An @mode word in the title picks the mode, like --mode does
+	title, titleMode, err := extractTitleMode(opts.Title)
+	if err != nil {
+		return nil, err
+	}
+	if titleMode != "" {
+		if opts.Mode != "" && !strings.EqualFold(opts.Mode, titleMode) {
+			return nil, fmt.Errorf("the title says @%s but the mode is '%s'", titleMode, opts.Mode)
+		}
+		opts.Mode = titleMode
+	}
+
+	mode, modeCfg, err := resolveMode(opts.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inline #tags in the title become regular tags
+	title, titleTags := extractTitleHashtags(title)
+	if title == "" {
+		title = "Untitled"
+	}
+	allTags := append(append([]string{}, modeCfg.DefaultTags...), opts.Tags...)
+	tags := NormalizeTags(append(allTags, titleTags...))
+
+	createdAt := time.Now().UTC()
+	if !opts.Date.IsZero() {
+		createdAt = opts.Date.UTC()
+	}
+	expiresAt, err := noteExpiry(opts.TTL, modeCfg, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate timestamp-based filename
-	timestamp := time.Now().UTC().Format("2006-01-02T15-04-05Z")
-	slug := slugify(title)
-	filename := fmt.Sprintf("%s-%s.md", timestamp, slug)
+	if filename == "" {
+		timestamp := time.Now().UTC().Format("2006-01-02T15-04-05Z")
+		slug := slugify(title)
+		filename = fmt.Sprintf("%s-%s.md", timestamp, slug)
+	}
 
-	notesDir := config.GetNotesDir()
+	notesDir := filepath.Join(config.GetNotesDir(), modeCfg.Directory)
+	if err := os.MkdirAll(notesDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create mode directory: %w", err)
+	}
 	filePath := filepath.Join(notesDir, filename)
 
 	// Generate short hash ID from filename
@@ -53,18 +124,47 @@ func (s *NoteService) CreateNote(title string, tags []string, mode string) (*mod
 		Mode:      mode,
 		FilePath:  filePath,
 		FileName:  filename,
-		CreatedAt: time.Now().UTC(),
+		CreatedAt: createdAt,
 		UpdatedAt: time.Now().UTC(),
 		Tags:      tags,
+		ExpiresAt: expiresAt,
+	}
+
+	// Create note content from the template, or a bare metadata header
+	templateName := opts.Template
+	if templateName == "" && opts.Body == "" {
+		templateName = modeCfg.Template
+	}
+
+	var content string
+	if templateName != "" {
+		rendered, err := renderTemplate(templateName, TemplateData{
+			Title: note.Title,
+			Mode:  note.Mode,
+			Tags:  note.Tags,
+			Date:  note.CreatedAt,
+			User:  currentUser(),
+			Vars:  opts.Vars,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		templateTags, extraFields, body := splitTemplateOutput(rendered)
+		note.Tags = NormalizeTags(append(note.Tags, templateTags...))
+		content = s.generateNoteContentWithBody(note, extraFields, body)
+	} else {
+		content = s.generateNoteContentWithBody(note, nil, opts.Body)
 	}
 
-	// Create note content with metadata header
-	content := s.generateNoteContent(note)
 	note.ContentHash = s.generateContentHash(content)
 	note.ContentPreview = s.generatePreview(content)
 	note.WordCount = s.countWords(content)
 
-	// Write file
+	// Write file, never overwriting an existing note
+	if _, err := os.Stat(filePath); err == nil {
+		return nil, fmt.Errorf("note file already exists: %s", filePath)
+	}
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return nil, fmt.Errorf("failed to write note file: %w", err)
 	}
@@ -76,26 +176,23 @@ func (s *NoteService) CreateNote(title string, tags []string, mode string) (*mod
 		return nil, fmt.Errorf("failed to save note to database: %w", err)
 	}
 
-	// Update FTS index
-	if err := s.updateFTSIndex(note, content); err != nil {
-		// Log warning but don't fail - FTS is optional
-		fmt.Printf("Warning: failed to update FTS index: %v\n", err)
-	}
-
-	// Open in editor
-	if err := s.openInEditor(filePath); err != nil {
-		return nil, fmt.Errorf("failed to open editor: %w", err)
+	// Update FTS index and tasks
+	if err := s.indexNoteContent(note, content); err != nil {
+		// Log warning but don't fail - the next sync will retry
+		fmt.Fprintf(os.Stderr, "Warning: failed to index note: %v\n", err)
 	}
 
 	return note, nil
 }
 
-// ListNotes returns notes with optional filtering
-func (s *NoteService) ListNotes(tagFilter, modeFilter string) ([]*models.Note, error) {
+// ListNotes returns notes with optional filtering, pinned notes first
+func (s *NoteService) ListNotes(tagFilter, modeFilter string, archived models.ArchiveFilter) ([]*models.Note, error) {
 	filter := models.DefaultListFilter()
+	filter.Archived = archived
+	filter.PinnedFirst = true
 
 	if tagFilter != "" {
-		filter.Tags = []string{tagFilter}
+		filter.Tags = []string{NormalizeTag(tagFilter)}
 	}
 	if modeFilter != "" {
 		filter.Mode = modeFilter
@@ -105,64 +202,247 @@ func (s *NoteService) ListNotes(tagFilter, modeFilter string) ([]*models.Note, e
 }
 
 // SearchNotes searches for notes by query string
-func (s *NoteService) SearchNotes(query string) ([]*models.SearchResult, error) {
-	return s.noteRepo.Search(query)
+func (s *NoteService) SearchNotes(query string, archived models.ArchiveFilter) ([]*models.SearchResult, error) {
+	query = expandQuerySynonyms(query)
+
+	results, err := s.noteRepo.Search(query, archived)
+	if err != nil {
+		return nil, err
+	}
+
+	s.locateMatches(results, query)
+	return results, nil
+}
+
+// OpenNote opens a note by ID, date or title and returns it as indexed
+// before editing. An #anchor suffix opens the editor at that heading.
+func (s *NoteService) OpenNote(identifier string) (*models.Note, error) {
+	note, line, err := s.resolveNoteAnchor(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.openInEditorAt(note.FilePath, note.Mode, line); err != nil {
+		return nil, err
+	}
+	s.recordAccess(note.ID, AccessOpen)
+
+	return note, nil
+}
+
+// OpenNoteAt opens a note in the editor with the cursor on a line
+func (s *NoteService) OpenNoteAt(note *models.Note, line int) error {
+	if err := s.openInEditorAt(note.FilePath, note.Mode, line); err != nil {
+		return err
+	}
+	s.recordAccess(note.ID, AccessOpen)
+	return nil
+}
+
+// ReadNote finds a note by ID, date or title and returns it with the
+// contents of its file
+func (s *NoteService) ReadNote(identifier string) (*models.Note, string, error) {
+	note, err := s.ResolveNote(identifier)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read note file: %w", err)
+	}
+	s.recordAccess(note.ID, AccessShow)
+
+	return note, string(content), nil
+}
+
+// resolveNoteAnchor resolves an identifier with an optional #heading suffix
+// to a note and the heading's line (0 without an anchor). A '#' can also be
+// part of a title, as in "Learning C#", so when the heading is not found the
+// whole identifier is tried as well, and a trailing '#' is ignored.
+func (s *NoteService) resolveNoteAnchor(identifier string) (*models.Note, int, error) {
+	i := strings.LastIndex(identifier, "#")
+	if i <= 0 {
+		note, err := s.ResolveNote(identifier)
+		return note, 0, err
+	}
+	base, anchor := identifier[:i], identifier[i+1:]
+
+	if anchor != "" {
+		if note, err := s.ResolveNote(base); err == nil {
+			line, anchorErr := s.resolveAnchor(note, anchor)
+			if anchorErr == nil {
+				return note, line, nil
+			}
+			if note, err := s.ResolveNote(identifier); err == nil {
+				return note, 0, nil
+			}
+			return nil, 0, anchorErr
+		}
+	}
+
+	note, err := s.ResolveNote(identifier)
+	if err != nil && anchor == "" {
+		if note, baseErr := s.ResolveNote(base); baseErr == nil {
+			return note, 0, nil
+		}
+	}
+	return note, 0, err
 }
 
-// OpenNote opens a note by ID or title
-func (s *NoteService) OpenNote(identifier string) error {
+// ResolveNote finds a note by exact ID, daily note date, partial ID or title.
+// Several notes matching a partial title resolve to the one used most.
+func (s *NoteService) ResolveNote(identifier string) (*models.Note, error) {
 	// Try to find by exact ID first
 	note, err := s.noteRepo.GetByID(identifier)
 	if err != nil {
-		return fmt.Errorf("database error: %w", err)
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if note != nil {
+		return note, nil
 	}
 
-	// If not found by exact ID, try partial ID match
-	if note == nil {
-		notes, err := s.ListNotes("", "")
-		if err != nil {
-			return fmt.Errorf("failed to list notes for partial search: %w", err)
+	notes, err := s.noteRepo.List(models.ListFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes for partial search: %w", err)
+	}
+
+	note, err = matchNote(identifier, notes)
+	var ambiguous *AmbiguousNoteError
+	if errors.As(err, &ambiguous) {
+		return s.rankCandidates(ambiguous)
+	}
+	return note, err
+}
+
+// matchNote resolves an identifier against a list of notes. It tries the
+// exact ID, a daily note date, a partial ID, the exact title and finally a
+// partial title, in that order.
+func matchNote(identifier string, notes []*models.Note) (*models.Note, error) {
+	byID := make(map[string]*models.Note, len(notes))
+	for _, n := range notes {
+		byID[n.ID] = n
+	}
+
+	if note, ok := byID[identifier]; ok {
+		return note, nil
+	}
+
+	// Dates like 2025-03-14 address that day's daily note
+	if day, ok := parseDay(identifier); ok {
+		if note, ok := byID[dailyNoteID(day)]; ok {
+			return note, nil
 		}
+	}
 
-		var matches []*models.Note
-		for _, n := range notes {
-			if strings.HasPrefix(n.ID, identifier) {
-				matches = append(matches, n)
-			}
+	// Partial ID match, like git commits
+	var matches []*models.Note
+	for _, n := range notes {
+		if strings.HasPrefix(n.ID, identifier) {
+			matches = append(matches, n)
 		}
+	}
 
-		if len(matches) == 1 {
-			note = matches[0]
-		} else if len(matches) > 1 {
-			var ids []string
-			for _, n := range matches {
-				ids = append(ids, n.ID)
-			}
-			return fmt.Errorf("ambiguous ID '%s', could match: %s",
-				identifier, strings.Join(ids, ", "))
+	if len(matches) == 1 {
+		return matches[0], nil
+	} else if len(matches) > 1 {
+		return nil, &AmbiguousNoteError{Identifier: identifier, Candidates: matches, byID: true}
+	}
+
+	// Exact title match wins over partial ones
+	lower := strings.ToLower(strings.TrimSpace(identifier))
+	for _, n := range notes {
+		if strings.ToLower(n.Title) == lower {
+			return n, nil
 		}
 	}
 
-	// If still not found, try partial title match
-	if note == nil {
-		notes, err := s.ListNotes("", "")
-		if err != nil {
-			return fmt.Errorf("failed to list notes for title search: %w", err)
+	for _, n := range notes {
+		if strings.Contains(strings.ToLower(n.Title), lower) {
+			matches = append(matches, n)
 		}
+	}
 
-		for _, n := range notes {
-			if strings.Contains(strings.ToLower(n.Title), strings.ToLower(identifier)) {
-				note = n
-				break
-			}
+	if len(matches) == 1 {
+		return matches[0], nil
+	} else if len(matches) > 1 {
+		return nil, &AmbiguousNoteError{Identifier: identifier, Candidates: matches}
+	}
+
+	return nil, fmt.Errorf("note not found: %s", identifier)
+}
+
+// AmbiguousNoteError is returned when an identifier matches several notes by
+// partial ID or partial title
+type AmbiguousNoteError struct {
+	Identifier string
+	Candidates []*models.Note
+	byID       bool
+}
+
+func (e *AmbiguousNoteError) Error() string {
+	var names []string
+	for _, n := range e.Candidates {
+		if e.byID {
+			names = append(names, n.ID)
+		} else {
+			names = append(names, fmt.Sprintf("%s (%s)", n.Title, n.ID))
 		}
 	}
 
-	if note == nil {
-		return fmt.Errorf("note not found: %s", identifier)
+	kind := "title"
+	if e.byID {
+		kind = "ID"
 	}
+	return fmt.Sprintf("ambiguous %s '%s', could match: %s", kind, e.Identifier, strings.Join(names, ", "))
+}
 
-	return s.openInEditor(note.FilePath)
+// SetTags replaces the tags in a note's frontmatter and re-indexes it.
+// Inline #tags in the body are left alone.
+func (s *NoteService) SetTags(note *models.Note, tags []string) (*models.Note, error) {
+	content, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	tagList := fmt.Sprintf("[%s]", strings.Join(NormalizeTags(tags), ", "))
+	updated := setFrontmatterField(string(content), "tags", tagList)
+	if err := os.WriteFile(note.FilePath, []byte(updated), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write note file: %w", err)
+	}
+
+	if err := s.syncNoteFromFile(note.FilePath, false); err != nil {
+		return nil, err
+	}
+	if err := s.noteRepo.PruneUnusedTags(); err != nil {
+		return nil, err
+	}
+
+	return s.noteRepo.GetByID(note.ID)
+}
+
+// DeleteNote removes a note's file and its index entries. Links to it from
+// other notes become broken.
+func (s *NoteService) DeleteNote(note *models.Note) error {
+	if err := os.Remove(note.FilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete note file: %w", err)
+	}
+	return s.unindexNote(note)
+}
+
+// unindexNote removes a note whose file is gone from the index
+func (s *NoteService) unindexNote(note *models.Note) error {
+	if err := s.deleteFTSIndex(note.ID); err != nil {
+		return fmt.Errorf("failed to remove note from search index: %w", err)
+	}
+	if err := s.noteRepo.Delete(note.ID); err != nil {
+		return err
+	}
+	if err := s.noteRepo.PruneUnusedTags(); err != nil {
+		return err
+	}
+
+	return s.resolveLinks()
 }
 
 // GetStats returns statistics about notes
@@ -174,15 +454,37 @@ func (s *NoteService) GetStats() (*models.StatsResult, error) {
 func (s *NoteService) SyncFromFileSystem() error {
 	notesDir := config.GetNotesDir()
 
-	files, err := filepath.Glob(filepath.Join(notesDir, "*.md"))
+	files, err := findNoteFiles(notesDir)
 	if err != nil {
 		return fmt.Errorf("failed to scan notes directory: %w", err)
 	}
 
+	// Re-index every note when the indexing rules have changed since the last sync
+	signature := indexSignature()
+	storedSignature, err := s.configRepo.Get("index_signature")
+	if err != nil {
+		return err
+	}
+	force := storedSignature != signature
+
 	for _, file := range files {
-		if err := s.syncNoteFromFile(file); err != nil {
+		if err := s.syncNoteFromFile(file, force); err != nil {
 			// Log error but continue with other files
-			fmt.Printf("Warning: failed to sync %s: %v\n", file, err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to sync %s: %v\n", file, err)
+		}
+	}
+
+	// Titles may have changed, so re-resolve links against the current notes
+	if err := s.resolveLinks(); err != nil {
+		return err
+	}
+
+	if force {
+		if err := s.noteRepo.PruneUnusedTags(); err != nil {
+			return err
+		}
+		if err := s.configRepo.Set("index_signature", signature); err != nil {
+			return err
 		}
 	}
 
@@ -191,7 +493,12 @@ func (s *NoteService) SyncFromFileSystem() error {
 
 // Helper functions
 
-func (s *NoteService) syncNoteFromFile(filePath string) error {
+// indexSignature describes the indexing rules so a change can trigger a re-index
+func indexSignature() string {
+	return fmt.Sprintf("index=%d;%s", indexVersion, tagPolicySignature())
+}
+
+func (s *NoteService) syncNoteFromFile(filePath string, force bool) error {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
@@ -217,20 +524,25 @@ func (s *NoteService) syncNoteFromFile(filePath string) error {
 		if err := s.noteRepo.Create(note); err != nil {
 			return err
 		}
-		// Update FTS index
-		return s.updateFTSIndex(note, string(content))
+		s.warnMissingFields(note, string(content))
+		// Update FTS index and tasks
+		return s.indexNoteContent(note, string(content))
 	} else {
 		// Update if content changed
 		newHash := s.generateContentHash(string(content))
-		if existingNote.ContentHash != newHash {
+		if force || existingNote.ContentHash != newHash {
 			note.ID = existingNote.ID               // Preserve ID
 			note.CreatedAt = existingNote.CreatedAt // Preserve creation time
 			note.ContentHash = newHash
+			if existingNote.ContentHash == newHash {
+				note.UpdatedAt = existingNote.UpdatedAt // Re-index only, content unchanged
+			}
 			if err := s.noteRepo.Update(note); err != nil {
 				return err
 			}
-			// Update FTS index
-			return s.updateFTSIndex(note, string(content))
+			s.warnMissingFields(note, string(content))
+			// Update FTS index and tasks
+			return s.indexNoteContent(note, string(content))
 		}
 	}
 
@@ -251,45 +563,23 @@ func (s *NoteService) parseNoteFile(filePath, content string) (*models.Note, err
 		UpdatedAt:      time.Now().UTC(),
 	}
 
-	// Parse metadata from content
-	lines := strings.Split(content, "\n")
-	inMetadata := false
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "---" {
-			if !inMetadata {
-				inMetadata = true
-				continue
-			} else {
-				break
-			}
-		}
-
-		if inMetadata {
-			if strings.HasPrefix(line, "title:") {
-				note.Title = strings.TrimSpace(strings.TrimPrefix(line, "title:"))
-				note.Title = strings.Trim(note.Title, "\"")
-			} else if strings.HasPrefix(line, "mode:") {
-				note.Mode = strings.TrimSpace(strings.TrimPrefix(line, "mode:"))
-				note.Mode = strings.Trim(note.Mode, "\"")
-			} else if strings.HasPrefix(line, "date:") {
-				dateStr := strings.TrimSpace(strings.TrimPrefix(line, "date:"))
-				dateStr = strings.Trim(dateStr, "\"")
-				if date, err := time.Parse(time.RFC3339, dateStr); err == nil {
-					note.CreatedAt = date
-				}
-			} else if strings.HasPrefix(line, "tags:") {
-				tagsStr := strings.TrimSpace(strings.TrimPrefix(line, "tags:"))
-				tagsStr = strings.Trim(tagsStr, "[]")
-				if tagsStr != "" {
-					tags := strings.Split(tagsStr, ",")
-					for i, tag := range tags {
-						tags[i] = strings.TrimSpace(tag)
-					}
-					note.Tags = tags
-				}
-			}
+	// Parse metadata from frontmatter
+	fields := frontmatterFields(content)
+	note.Title = fields["title"]
+	note.Mode = fields["mode"]
+	if date, err := time.Parse(time.RFC3339, fields["date"]); err == nil {
+		note.CreatedAt = date
+	}
+	note.Tags = NormalizeTags(parseTagList(fields["tags"]))
+	note.Pinned, _ = strconv.ParseBool(fields["pinned"])
+	note.Archived, _ = strconv.ParseBool(fields["archived"])
+	note.ExpiresAt = parseExpiry(fields["expires"])
+
+	// Merge inline #tags from the body, tracked separately from frontmatter tags
+	for _, tag := range NormalizeTags(extractHashtags(content)) {
+		if !containsString(note.Tags, tag) {
+			note.Tags = append(note.Tags, tag)
+			note.BodyTags = append(note.BodyTags, tag)
 		}
 	}
 
@@ -307,6 +597,35 @@ func (s *NoteService) parseNoteFile(filePath, content string) (*models.Note, err
 	return note, nil
 }
 
+// findNoteFiles returns every .md file under the notes directory, including mode subdirectories
+func findNoteFiles(notesDir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(notesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != notesDir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".md" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// warnMissingFields prints a warning when a note leaves required fields for its mode empty
+func (s *NoteService) warnMissingFields(note *models.Note, content string) {
+	if missing := missingRequiredFields(note.Mode, content); len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %s (%s) is missing required %s fields: %s\n",
+			note.ID, note.Title, note.Mode, strings.Join(missing, ", "))
+	}
+}
+
 func generateShortID(filename string) string {
 	h := sha1.New()
 	h.Write([]byte(filename))
@@ -321,6 +640,11 @@ func slugify(text string) string {
 }
 
 func (s *NoteService) generateNoteContent(note *models.Note) string {
+	return s.generateNoteContentWithBody(note, nil, "")
+}
+
+// generateNoteContentWithBody builds the frontmatter, extra fields and body of a note
+func (s *NoteService) generateNoteContentWithBody(note *models.Note, extraFields []string, body string) string {
 	tagsStr := ""
 	if len(note.Tags) > 0 {
 		tagsStr = fmt.Sprintf("[%s]", strings.Join(note.Tags, ", "))
@@ -328,18 +652,43 @@ func (s *NoteService) generateNoteContent(note *models.Note) string {
 		tagsStr = "[]"
 	}
 
+	// Leave placeholders for the fields the mode requires
+	provided := make(map[string]bool)
+	for _, line := range extraFields {
+		key, _, _ := strings.Cut(line, ":")
+		provided[strings.TrimSpace(key)] = true
+	}
+	if modeCfg, ok := config.GetMode(note.Mode); ok {
+		for _, field := range modeCfg.RequiredFields {
+			if !provided[field] {
+				extraFields = append(extraFields, field+": ")
+			}
+		}
+	}
+
+	if note.ExpiresAt != nil {
+		extraFields = append([]string{"expires: " + note.ExpiresAt.Format(time.RFC3339)}, extraFields...)
+	}
+
+	fieldsStr := ""
+	for _, line := range extraFields {
+		fieldsStr += line + "\n"
+	}
+
 	return fmt.Sprintf(`---
 title: %s
 tags: %s
 mode: %s
 date: %s
----
+%s---
 
-`,
+%s`,
 		note.Title,
 		tagsStr,
 		note.Mode,
 		note.CreatedAt.Format(time.RFC3339),
+		fieldsStr,
+		body,
 	)
 }
 
@@ -384,27 +733,60 @@ func (s *NoteService) countWords(content string) int {
 	return len(words)
 }
 
-func (s *NoteService) openInEditor(filePath string) error {
-	// This is the same as the original openInEditor function
-	editor := config.AppConfig.Editor
-	cmd := exec.Command(editor, filePath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// indexNoteContent updates everything derived from a note's content
+func (s *NoteService) indexNoteContent(note *models.Note, content string) error {
+	tx, err := s.noteRepo.GetDB().Connection().Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.indexNoteContentTx(tx, note, content); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// updateFTSIndex updates the full-text search index for a note
-func (s *NoteService) updateFTSIndex(note *models.Note, content string) error {
-	// Get database connection
-	db := s.noteRepo.GetDB()
+// indexNoteContentTx updates the FTS index, tasks, links and headings of a note inside a transaction
+func (s *NoteService) indexNoteContentTx(tx *sql.Tx, note *models.Note, content string) error {
+	if err := updateFTSIndex(tx, note, content); err != nil {
+		return fmt.Errorf("failed to update FTS index: %w", err)
+	}
+
+	if err := s.taskRepo.ReplaceForNoteTx(tx, note.ID, parseTasks(note.ID, content)); err != nil {
+		return fmt.Errorf("failed to update tasks: %w", err)
+	}
+
+	if err := s.linkRepo.ReplaceForNoteTx(tx, note.ID, parseLinks(content)); err != nil {
+		return fmt.Errorf("failed to update links: %w", err)
+	}
+
+	if err := s.headingRepo.ReplaceForNoteTx(tx, note.ID, parseHeadings(note.ID, content)); err != nil {
+		return fmt.Errorf("failed to update headings: %w", err)
+	}
 
+	attachments := parseAttachments(filepath.Dir(note.FilePath), config.GetAssetsDir(), content)
+	if err := s.assetRepo.ReplaceForNoteTx(tx, note.ID, attachments); err != nil {
+		return fmt.Errorf("failed to update attachments: %w", err)
+	}
+
+	return nil
+}
+
+// updateFTSIndex updates the full-text search index for a note
+func updateFTSIndex(tx *sql.Tx, note *models.Note, content string) error {
 	// Prepare tags string for FTS
 	tagsStr := strings.Join(note.Tags, " ")
 
-	// Insert or replace in FTS table
-	query := `INSERT OR REPLACE INTO notes_fts (note_id, title, content, tags) VALUES (?, ?, ?, ?)`
-	_, err := db.Connection().Exec(query, note.ID, note.Title, content, tagsStr)
+	// FTS5 tables have no key for INSERT OR REPLACE to replace on, so the
+	// note's old row is deleted first
+	if _, err := tx.Exec(`DELETE FROM notes_fts WHERE note_id = ?`, note.ID); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO notes_fts (note_id, title, content, tags) VALUES (?, ?, ?, ?)`
+	_, err := tx.Exec(query, note.ID, note.Title, content, tagsStr)
 
 	return err
 }
@@ -419,3 +801,12 @@ func (s *NoteService) deleteFTSIndex(noteID string) error {
 
 	return err
 }
+
+func containsString(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
@@ -234,3 +234,37 @@ func TestCountWords(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveNoteAnchor(t *testing.T) {
+	s := newTestService(t, map[string]string{
+		"csharp.md":  "---\ntitle: Learning C#\nmode: dev\n---\n\n## Basics\n\nClasses.\n",
+		"offsets.md": "---\ntitle: Offset resets\nmode: dev\n---\n\nIntro\n\n## Rollback plan\n\nReset.\n",
+	})
+
+	tests := []struct {
+		identifier string
+		title      string
+		line       int
+		wantErr    bool
+	}{
+		{"Learning C#", "Learning C#", 0, false},
+		{"Learning C##basics", "Learning C#", 6, false},
+		{"Offset resets#rollback-plan", "Offset resets", 8, false},
+		{"Offset resets#", "Offset resets", 0, false},
+		{"Offset resets#nope", "", 0, true},
+	}
+
+	for _, test := range tests {
+		note, line, err := s.resolveNoteAnchor(test.identifier)
+		if (err != nil) != test.wantErr {
+			t.Errorf("resolveNoteAnchor(%q) error = %v, wantErr %v", test.identifier, err, test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if note.Title != test.title || line != test.line {
+			t.Errorf("resolveNoteAnchor(%q) = %q line %d, expected %q line %d", test.identifier, note.Title, line, test.title, test.line)
+		}
+	}
+}
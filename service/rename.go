@@ -0,0 +1,264 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sk25469/jot/models"
+)
+
+// RenamePlan describes a note rename and the [[link]] rewrites it causes in
+// other notes. Nothing is written until the plan is applied.
+type RenamePlan struct {
+	Note     *models.Note
+	OldTitle string
+	NewTitle string
+	Rewrites []*models.LinkRewrite
+
+	paths     []string                // Files to re-index, in order
+	notes     map[string]*models.Note // Indexed note by file path
+	originals map[string]string       // Content on disk by file path
+	contents  map[string]string       // Content to write by file path
+}
+
+// PlanRename prepares renaming a note. With propagate, links to the old
+// title in other notes are rewritten to the new one.
+func (s *NoteService) PlanRename(identifier, newTitle string, propagate bool) (*RenamePlan, error) {
+	note, err := s.ResolveNote(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	newTitle = strings.TrimSpace(newTitle)
+	if newTitle == "" {
+		return nil, fmt.Errorf("new title cannot be empty")
+	}
+	if newTitle == note.Title {
+		return nil, fmt.Errorf("note %s is already titled %q", note.ID, newTitle)
+	}
+
+	content, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	plan := newRenamePlan(note, note.Title, newTitle)
+	plan.addFile(note, string(content), setFrontmatterTitle(string(content), newTitle))
+
+	if propagate {
+		if err := s.planLinkRewrites(plan); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// PlanTitleChange checks whether a note's title was changed in its file,
+// e.g. in the editor, and prepares rewriting the links to the old title.
+// It returns nil when there is nothing to propagate.
+func (s *NoteService) PlanTitleChange(note *models.Note) (*RenamePlan, error) {
+	content, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	newTitle := frontmatterFields(string(content))["title"]
+	if newTitle == "" || newTitle == note.Title {
+		return nil, nil
+	}
+
+	plan := newRenamePlan(note, note.Title, newTitle)
+	plan.addFile(note, string(content), string(content))
+
+	if err := s.planLinkRewrites(plan); err != nil {
+		return nil, err
+	}
+	if len(plan.Rewrites) == 0 {
+		return nil, nil
+	}
+
+	return plan, nil
+}
+
+// ApplyRename writes the planned files and re-indexes all of them in a
+// single transaction. If anything fails, the files are restored.
+func (s *NoteService) ApplyRename(plan *RenamePlan) error {
+	tx, err := s.noteRepo.GetDB().Connection().Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, path := range plan.paths {
+		existing := plan.notes[path]
+		content := plan.contents[path]
+
+		note, err := s.parseNoteFile(path, content)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		note.ID = existing.ID               // Preserve ID
+		note.CreatedAt = existing.CreatedAt // Preserve creation time
+
+		if err := s.noteRepo.UpdateTx(tx, note); err != nil {
+			return err
+		}
+		if err := s.indexNoteContentTx(tx, note, content); err != nil {
+			return err
+		}
+	}
+
+	var written []string
+	restore := func() {
+		for _, path := range written {
+			os.WriteFile(path, []byte(plan.originals[path]), 0644)
+		}
+	}
+
+	for _, path := range plan.paths {
+		if plan.contents[path] == plan.originals[path] {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(plan.contents[path]), 0644); err != nil {
+			restore()
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	if err := tx.Commit(); err != nil {
+		restore()
+		return fmt.Errorf("failed to commit rename: %w", err)
+	}
+
+	// Point the rewritten links at the renamed note
+	return s.resolveLinks()
+}
+
+func newRenamePlan(note *models.Note, oldTitle, newTitle string) *RenamePlan {
+	return &RenamePlan{
+		Note:      note,
+		OldTitle:  oldTitle,
+		NewTitle:  newTitle,
+		notes:     make(map[string]*models.Note),
+		originals: make(map[string]string),
+		contents:  make(map[string]string),
+	}
+}
+
+func (p *RenamePlan) addFile(note *models.Note, original, content string) {
+	if _, ok := p.notes[note.FilePath]; !ok {
+		p.paths = append(p.paths, note.FilePath)
+	}
+	p.notes[note.FilePath] = note
+	p.originals[note.FilePath] = original
+	p.contents[note.FilePath] = content
+}
+
+// planLinkRewrites adds every note linking to the old title to the plan
+func (s *NoteService) planLinkRewrites(plan *RenamePlan) error {
+	links, err := s.linkRepo.ListIncoming(plan.Note.ID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, link := range links {
+		if !strings.EqualFold(link.Target, plan.OldTitle) || seen[link.SourceID] {
+			continue
+		}
+		seen[link.SourceID] = true
+
+		source, err := s.noteRepo.GetByID(link.SourceID)
+		if err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		if source == nil {
+			continue
+		}
+
+		// The renamed note may link to itself, so build on its pending content
+		original, ok := plan.originals[source.FilePath]
+		content := plan.contents[source.FilePath]
+		if !ok {
+			data, err := os.ReadFile(source.FilePath)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", source.FilePath, err)
+			}
+			original, content = string(data), string(data)
+		}
+
+		rewritten, rewrites := rewriteLinks(content, plan.OldTitle, plan.NewTitle)
+		if len(rewrites) == 0 {
+			continue
+		}
+
+		for _, rewrite := range rewrites {
+			rewrite.NoteID = source.ID
+			rewrite.NoteTitle = source.Title
+		}
+		plan.Rewrites = append(plan.Rewrites, rewrites...)
+		plan.addFile(source, original, rewritten)
+	}
+
+	return nil
+}
+
+// rewriteLinks replaces [[old title]] links with [[new title]], keeping any
+// #anchor and |alias and leaving code untouched
+func rewriteLinks(content, oldTitle, newTitle string) (string, []*models.LinkRewrite) {
+	lines := strings.Split(content, "\n")
+
+	var rewrites []*models.LinkRewrite
+	for _, line := range bodyLines(content) {
+		if line.InCode {
+			continue
+		}
+
+		before := lines[line.Number-1]
+		after := replaceOutsideInlineCode(before, func(text string) string {
+			return wikiLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+				parts := wikiLinkPattern.FindStringSubmatch(match)
+				target, anchor, hasAnchor := strings.Cut(parts[1], "#")
+				if !strings.EqualFold(strings.TrimSpace(target), oldTitle) {
+					return match
+				}
+
+				link := newTitle
+				if hasAnchor {
+					link += "#" + anchor
+				}
+				if strings.Contains(match, "|") {
+					link += "|" + parts[2]
+				}
+				return "[[" + link + "]]"
+			})
+		})
+
+		if after != before {
+			lines[line.Number-1] = after
+			rewrites = append(rewrites, &models.LinkRewrite{
+				Line:   line.Number,
+				Before: strings.TrimSpace(before),
+				After:  strings.TrimSpace(after),
+			})
+		}
+	}
+
+	return strings.Join(lines, "\n"), rewrites
+}
+
+// replaceOutsideInlineCode applies replace to the parts of a line outside `code` spans
+func replaceOutsideInlineCode(line string, replace func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range inlineCodePattern.FindAllStringIndex(line, -1) {
+		b.WriteString(replace(line[last:span[0]]))
+		b.WriteString(line[span[0]:span[1]])
+		last = span[1]
+	}
+	b.WriteString(replace(line[last:]))
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestRewriteLinks(t *testing.T) {
+	content := "---\ntitle: Standup\n---\n\n" +
+		"See [[Offset reset]] and [[offset reset|the fix]].\n" +
+		"Jump to [[Offset reset#Rollback|rolling back]].\n" +
+		"Keep [[Offset reset notes]] and `[[Offset reset]]` as they are.\n" +
+		"```\n[[Offset reset]]\n```\n"
+
+	rewritten, rewrites := rewriteLinks(content, "Offset reset", "Consumer offset reset")
+
+	expected := "---\ntitle: Standup\n---\n\n" +
+		"See [[Consumer offset reset]] and [[Consumer offset reset|the fix]].\n" +
+		"Jump to [[Consumer offset reset#Rollback|rolling back]].\n" +
+		"Keep [[Offset reset notes]] and `[[Offset reset]]` as they are.\n" +
+		"```\n[[Offset reset]]\n```\n"
+	if rewritten != expected {
+		t.Errorf("rewriteLinks() content =\n%s\nexpected\n%s", rewritten, expected)
+	}
+
+	if len(rewrites) != 2 {
+		t.Fatalf("rewriteLinks() returned %d rewrites, expected 2", len(rewrites))
+	}
+	if rewrites[0].Line != 5 || rewrites[0].Before != "See [[Offset reset]] and [[offset reset|the fix]]." {
+		t.Errorf("Unexpected rewrite: %+v", rewrites[0])
+	}
+}
+
+func TestSetFrontmatterTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "replaces existing title",
+			content:  "---\ntitle: Old\nmode: dev\n---\n\nBody\ntitle: not frontmatter\n",
+			expected: "---\ntitle: New\nmode: dev\n---\n\nBody\ntitle: not frontmatter\n",
+		},
+		{
+			name:     "adds missing title",
+			content:  "---\nmode: dev\n---\n\nBody\n",
+			expected: "---\ntitle: New\nmode: dev\n---\n\nBody\n",
+		},
+		{
+			name:     "adds frontmatter",
+			content:  "Body\n",
+			expected: "---\ntitle: New\n---\n\nBody\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := setFrontmatterTitle(test.content, "New"); got != test.expected {
+				t.Errorf("setFrontmatterTitle() =\n%q\nexpected\n%q", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+// ReviewPeriod is the time range a review note covers
+type ReviewPeriod struct {
+	Name  string // "week", "month" or "since"
+	Title string
+	Since time.Time
+	Until time.Time
+}
+
+// WeekPeriod covers the current week, starting on Monday
+func WeekPeriod(now time.Time) ReviewPeriod {
+	start := startOfDay(now)
+	offset := (int(start.Weekday()) + 6) % 7 // Days since Monday
+	start = start.AddDate(0, 0, -offset)
+
+	year, week := start.ISOWeek()
+	return ReviewPeriod{
+		Name:  "week",
+		Title: fmt.Sprintf("Weekly review %d-W%02d", year, week),
+		Since: start,
+		Until: now,
+	}
+}
+
+// MonthPeriod covers the current calendar month
+func MonthPeriod(now time.Time) ReviewPeriod {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return ReviewPeriod{
+		Name:  "month",
+		Title: "Monthly review " + start.Format("2006-01"),
+		Since: start,
+		Until: now,
+	}
+}
+
+// SincePeriod covers everything from a given day until now
+func SincePeriod(since, now time.Time) ReviewPeriod {
+	since = startOfDay(since)
+	return ReviewPeriod{
+		Name:  "since",
+		Title: "Review since " + since.Format(dayLayout),
+		Since: since,
+		Until: now,
+	}
+}
+
+// CreateReview generates a review note for a period and opens it in the editor
+func (s *NoteService) CreateReview(period ReviewPeriod) (*models.Note, error) {
+	activity, err := s.statsRepo.GetActivityBetween(period.Since.UTC(), period.Until.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.statsRepo.GetStats()
+	if err != nil {
+		return nil, err
+	}
+
+	summary, vars := s.buildReviewSummary(period, activity, stats)
+
+	// Use the review template if it exists, otherwise the summary is the body
+	reviewCfg := config.AppConfig.Review
+	opts := models.CreateOptions{
+		Title: period.Title,
+		Tags:  []string{"review"},
+		Mode:  reviewCfg.Mode,
+		Vars:  vars,
+		Body:  summary + "\n## Reflections\n\n",
+	}
+	if reviewCfg.Template != "" {
+		if path, err := templatePath(reviewCfg.Template); err == nil {
+			if _, err := os.Stat(path); err == nil {
+				opts.Template = reviewCfg.Template
+			}
+		}
+	}
+
+	note, err := s.createNote(opts, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.openInEditor(note.FilePath, note.Mode); err != nil {
+		return nil, fmt.Errorf("failed to open editor: %w", err)
+	}
+	s.recordAccess(note.ID, AccessOpen)
+
+	return note, nil
+}
+
+// buildReviewSummary renders the activity of a period as Markdown along
+// with the template variables exposed to review templates
+func (s *NoteService) buildReviewSummary(period ReviewPeriod, activity []*models.Note, stats *models.StatsResult) (string, map[string]string) {
+	var created, updated, words int
+	byMode := make(map[string][]*models.Note)
+	byTag := make(map[string][]*models.Note)
+
+	for _, note := range activity {
+		if !note.CreatedAt.Before(period.Since) {
+			created++
+			words += note.WordCount
+		} else {
+			updated++
+		}
+
+		byMode[note.Mode] = append(byMode[note.Mode], note)
+		for _, tag := range note.Tags {
+			byTag[tag] = append(byTag[tag], note)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Summary\n\n")
+	fmt.Fprintf(&b, "- Period: %s to %s\n", period.Since.Format(dayLayout), period.Until.Format(dayLayout))
+	fmt.Fprintf(&b, "- Notes created: %d\n", created)
+	fmt.Fprintf(&b, "- Notes updated: %d\n", updated)
+	fmt.Fprintf(&b, "- Words written: %d\n", words)
+	fmt.Fprintf(&b, "- Total notes: %d (%d total words)\n", stats.TotalNotes, stats.WordCount)
+
+	writeReviewGroups(&b, "By mode", byMode)
+	writeReviewGroups(&b, "By tag", byTag)
+
+	fmt.Fprintf(&b, "\n## Open tasks\n\n")
+	openTasks := 0
+	for _, note := range activity {
+		for _, task := range s.openTasksInFile(note.FilePath) {
+			// Plain bullets, so the review does not duplicate the tasks
+			fmt.Fprintf(&b, "- %s — %s (%s)\n", task, note.Title, note.ID)
+			openTasks++
+		}
+	}
+	if openTasks == 0 {
+		fmt.Fprintf(&b, "No open tasks.\n")
+	}
+
+	summary := b.String()
+	vars := map[string]string{
+		"period":     period.Name,
+		"since":      period.Since.Format(dayLayout),
+		"until":      period.Until.Format(dayLayout),
+		"created":    strconv.Itoa(created),
+		"updated":    strconv.Itoa(updated),
+		"words":      strconv.Itoa(words),
+		"open_tasks": strconv.Itoa(openTasks),
+		"total":      strconv.Itoa(stats.TotalNotes),
+		"summary":    summary,
+	}
+
+	return summary, vars
+}
+
+// writeReviewGroups writes one section per group, largest first
+func writeReviewGroups(b *strings.Builder, heading string, groups map[string][]*models.Note) {
+	if len(groups) == 0 {
+		return
+	}
+
+	var names []string
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(groups[names[i]]) != len(groups[names[j]]) {
+			return len(groups[names[i]]) > len(groups[names[j]])
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Fprintf(b, "\n## %s\n", heading)
+	for _, name := range names {
+		fmt.Fprintf(b, "\n### %s (%d)\n\n", name, len(groups[name]))
+		for _, note := range groups[name] {
+			fmt.Fprintf(b, "- %s (%s) — %s\n", note.Title, note.ID, note.CreatedAt.Format(dayLayout))
+		}
+	}
+}
+
+// openTasksInFile returns the unchecked checkbox items in a note file
+func (s *NoteService) openTasksInFile(filePath string) []string {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+
+	var tasks []string
+	for _, task := range parseTasks("", string(content)) {
+		if !task.Done {
+			tasks = append(tasks, task.Text)
+		}
+	}
+	return tasks
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sk25469/jot/models"
+)
+
+func TestWeekPeriod(t *testing.T) {
+	// Thursday 2025-03-13
+	now := time.Date(2025, 3, 13, 15, 0, 0, 0, time.UTC)
+	period := WeekPeriod(now)
+
+	expectedStart := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	if !period.Since.Equal(expectedStart) {
+		t.Errorf("WeekPeriod().Since = %v, expected %v", period.Since, expectedStart)
+	}
+	if period.Title != "Weekly review 2025-W11" {
+		t.Errorf("WeekPeriod().Title = %q, expected %q", period.Title, "Weekly review 2025-W11")
+	}
+
+	// Sunday belongs to the week that started on the previous Monday
+	sunday := WeekPeriod(time.Date(2025, 3, 16, 9, 0, 0, 0, time.UTC))
+	if !sunday.Since.Equal(expectedStart) {
+		t.Errorf("WeekPeriod() on Sunday should start on %v, got %v", expectedStart, sunday.Since)
+	}
+}
+
+func TestMonthPeriod(t *testing.T) {
+	period := MonthPeriod(time.Date(2025, 3, 13, 15, 0, 0, 0, time.UTC))
+
+	expectedStart := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	if !period.Since.Equal(expectedStart) {
+		t.Errorf("MonthPeriod().Since = %v, expected %v", period.Since, expectedStart)
+	}
+	if period.Title != "Monthly review 2025-03" {
+		t.Errorf("MonthPeriod().Title = %q, expected %q", period.Title, "Monthly review 2025-03")
+	}
+}
+
+func TestBuildReviewSummary(t *testing.T) {
+	dir := t.TempDir()
+	notePath := filepath.Join(dir, "note.md")
+	content := "---\ntitle: Fix offset reset\n---\n\n- [ ] follow up with infra\n- [x] restart consumers\n"
+	if err := os.WriteFile(notePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	period := WeekPeriod(time.Date(2025, 3, 13, 15, 0, 0, 0, time.UTC))
+	activity := []*models.Note{
+		{
+			ID: "f4f1c39", Title: "Fix offset reset", Mode: "dev", FilePath: notePath,
+			CreatedAt: time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC), WordCount: 40,
+			Tags: []string{"kafka"},
+		},
+		{
+			ID: "5f3f8ed", Title: "Old design doc", Mode: "dev", FilePath: filepath.Join(dir, "missing.md"),
+			CreatedAt: time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC), WordCount: 500,
+			Tags: []string{"kafka", "design"},
+		},
+	}
+	stats := &models.StatsResult{TotalNotes: 12, WordCount: 3000}
+
+	service := &NoteService{}
+	summary, vars := service.buildReviewSummary(period, activity, stats)
+
+	expectations := []string{
+		"- Notes created: 1",
+		"- Notes updated: 1",
+		"- Words written: 40",
+		"- Total notes: 12",
+		"### dev (2)",
+		"### kafka (2)",
+		"### design (1)",
+		"- follow up with infra — Fix offset reset (f4f1c39)",
+	}
+	for _, expected := range expectations {
+		if !strings.Contains(summary, expected) {
+			t.Errorf("Summary should contain %q, got:\n%s", expected, summary)
+		}
+	}
+
+	if strings.Contains(summary, "restart consumers") {
+		t.Errorf("Summary should not list completed tasks")
+	}
+	if vars["created"] != "1" || vars["open_tasks"] != "1" || vars["summary"] != summary {
+		t.Errorf("Unexpected review variables: %v", vars)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"os"
+	"strings"
+
+	"github.com/sk25469/jot/models"
+)
+
+// locateMatches fills in the first matching line of each search result and
+// the heading that encloses it
+func (s *NoteService) locateMatches(results []*models.SearchResult, query string) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return
+	}
+
+	for _, result := range results {
+		content, err := os.ReadFile(result.FilePath)
+		if err != nil {
+			continue
+		}
+
+		result.Line = firstMatchLine(string(content), terms)
+		if result.Line == 0 {
+			continue
+		}
+
+		headings, err := s.headingRepo.ListForNote(result.ID)
+		if err != nil {
+			continue
+		}
+		if heading := enclosingHeading(headings, result.Line); heading != nil {
+			result.Heading = heading.Text
+		}
+	}
+}
+
+// searchTerms returns the lower-cased words of a query, without FTS operators
+func searchTerms(query string) []string {
+	var terms []string
+	for _, term := range strings.Fields(query) {
+		switch term {
+		case "AND", "OR", "NOT", "NEAR":
+			continue
+		}
+		term = strings.ToLower(strings.Trim(term, `"*()`))
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// firstMatchLine returns the first body line containing any of the terms, or 0
+func firstMatchLine(content string, terms []string) int {
+	for _, line := range bodyLines(content) {
+		text := strings.ToLower(line.Text)
+		for _, term := range terms {
+			if strings.Contains(text, term) {
+				return line.Number
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/database"
+	"github.com/sk25469/jot/models"
+)
+
+// newTestService returns a service over a temporary notes directory and
+// database, with the given files written to the notes directory and synced
+func newTestService(t *testing.T, files map[string]string) *NoteService {
+	t.Helper()
+	withModes(t, map[string]config.ModeConfig{"dev": {}})
+	dir := t.TempDir()
+	config.AppConfig.StoragePath = filepath.Join(dir, "notes")
+	if err := os.MkdirAll(config.AppConfig.StoragePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(config.AppConfig.StoragePath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, err := database.New(database.Config{Path: filepath.Join(dir, "jot.db")})
+	if err != nil {
+		t.Fatalf("database.New() error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	s := NewNoteService(db)
+	if err := s.SyncFromFileSystem(); err != nil {
+		t.Fatalf("SyncFromFileSystem() error: %v", err)
+	}
+	return s
+}
+
+func TestSearchNotesPastPreview(t *testing.T) {
+	// A long runbook whose match is far past the stored content preview
+	var body strings.Builder
+	for i := 0; i < 120; i++ {
+		body.WriteString("Step filler text for the runbook.\n")
+	}
+	body.WriteString("\n## Rollback\n\nRun zanzibarterm against the primary.\n")
+	content := "---\ntitle: Runbook\nmode: dev\n---\n\n" + body.String()
+
+	s := newTestService(t, map[string]string{"runbook.md": content})
+
+	results, err := s.SearchNotes("zanzibarterm", models.ExcludeArchived)
+	if err != nil {
+		t.Fatalf("SearchNotes() error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("SearchNotes() = %d results, expected 1", len(results))
+	}
+
+	result := results[0]
+	expectedLine := strings.Count(content[:strings.Index(content, "Run zanzibarterm")], "\n") + 1
+	if result.MatchType != "fts" || result.Line != expectedLine || result.Heading != "Rollback" {
+		t.Errorf("SearchNotes() = match %q, line %d, heading %q, expected fts, %d, Rollback",
+			result.MatchType, result.Line, result.Heading, expectedLine)
+	}
+}
+
+func TestSearchNotesSynonyms(t *testing.T) {
+	withTagConfig(t, config.TagConfig{CaseFold: true, Separator: "-", Synonyms: map[string]string{"k8s": "kubernetes", "ir": "incident response"}})
+	s := newTestService(t, map[string]string{
+		"outage.md":    "---\ntitle: Outage\nmode: dev\ntags: [ir]\n---\n\nPaged at night.\n",
+		"alias.md":     "---\ntitle: Cluster notes\nmode: dev\n---\n\nThe k8s upgrade went fine.\n",
+		"canonical.md": "---\ntitle: Node pools\nmode: dev\ntags: [k8s]\n---\n\nResize the pools.\n",
+	})
+
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{"k8s", []string{"Cluster notes", "Node pools"}},
+		{`"k8s upgrade"`, []string{"Cluster notes"}},
+		{"ir", []string{"Outage"}},
+		{"incident-response", []string{"Outage"}},
+	}
+
+	for _, tc := range testCases {
+		results, err := s.SearchNotes(tc.query, models.ExcludeArchived)
+		if err != nil {
+			t.Fatalf("SearchNotes(%q) error: %v", tc.query, err)
+		}
+		var titles []string
+		for _, result := range results {
+			titles = append(titles, result.Title)
+		}
+		sort.Strings(titles)
+		if strings.Join(titles, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("SearchNotes(%q) found %v, expected %v", tc.query, titles, tc.expected)
+		}
+	}
+}
+
+func TestSearchNotesAfterEdits(t *testing.T) {
+	s := newTestService(t, map[string]string{"zoo.md": "---\ntitle: Zoo\nmode: dev\n---\n\nA zebra.\n"})
+	path := filepath.Join(config.AppConfig.StoragePath, "zoo.md")
+
+	// Each edit re-indexes the note, which must replace its FTS row
+	for _, body := range []string{"A zebra and a lion.\n", "A zebra, a lion and a tiger.\n"} {
+		if err := os.WriteFile(path, []byte("---\ntitle: Zoo\nmode: dev\n---\n\n"+body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SyncFromFileSystem(); err != nil {
+			t.Fatalf("SyncFromFileSystem() error: %v", err)
+		}
+	}
+
+	results, err := s.SearchNotes("zebra", models.ExcludeArchived)
+	if err != nil {
+		t.Fatalf("SearchNotes() error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("SearchNotes() = %d results, expected the edited note once", len(results))
+	}
+	var rows int
+	if err := s.noteRepo.GetDB().Connection().QueryRow("SELECT COUNT(*) FROM notes_fts").Scan(&rows); err != nil || rows != 1 {
+		t.Errorf("notes_fts has %d rows, %v, expected 1", rows, err)
+	}
+	if tigers, err := s.SearchNotes("tiger", models.ExcludeArchived); err != nil || len(tigers) != 1 {
+		t.Errorf("SearchNotes(tiger) = %d results, %v, expected the latest content to be indexed", len(tigers), err)
+	}
+}
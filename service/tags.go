@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/sk25469/jot/config"
+)
+
+var tagSeparatorPattern = regexp.MustCompile(`[\s_-]+`)
+
+// NormalizeTag applies the configured tag policy and resolves synonyms
+func NormalizeTag(tag string) string {
+	tag = normalizeTagForm(tag)
+	if tag == "" {
+		return ""
+	}
+
+	for alias, canonical := range config.AppConfig.Tags.Synonyms {
+		if normalizeTagForm(alias) == tag {
+			return normalizeTagForm(canonical)
+		}
+	}
+
+	return tag
+}
+
+// NormalizeTags normalises a list of tags, dropping empty and duplicate entries
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// normalizeTagForm applies NFC, case folding and separator rules without synonyms
+func normalizeTagForm(tag string) string {
+	policy := config.AppConfig.Tags
+
+	tag = strings.TrimSpace(tag)
+	if policy.NFC {
+		tag = norm.NFC.String(tag)
+	}
+	if policy.CaseFold {
+		tag = cases.Fold().String(tag)
+	}
+
+	tag = tagSeparatorPattern.ReplaceAllString(tag, policy.Separator)
+	if policy.Separator != "" {
+		tag = strings.Trim(tag, policy.Separator)
+	}
+
+	return tag
+}
+
+// expandQuerySynonyms widens each bare word of a search query that is a tag
+// alias to (alias OR "canonical"), so a search finds notes that use either.
+// Quoted phrases, operators and words FTS would not read as a plain term are
+// left untouched.
+func expandQuerySynonyms(query string) string {
+	if len(config.AppConfig.Tags.Synonyms) == 0 {
+		return query
+	}
+
+	var b strings.Builder
+	inPhrase := false
+	word := 0
+	flush := func(end int) {
+		if end > word {
+			b.WriteString(expandQueryWord(query[word:end]))
+		}
+	}
+
+	for i, r := range query {
+		switch {
+		case r == '"':
+			if !inPhrase {
+				flush(i)
+			}
+			inPhrase = !inPhrase
+			b.WriteRune(r)
+			word = i + 1
+		case inPhrase:
+			b.WriteRune(r)
+			word = i + 1
+		case unicode.IsSpace(r) || r == '(' || r == ')':
+			flush(i)
+			b.WriteRune(r)
+			word = i + 1
+		}
+	}
+	if !inPhrase {
+		flush(len(query))
+	}
+
+	return b.String()
+}
+
+// expandQueryWord returns a query word, or (word OR "canonical") when it is a
+// tag alias
+func expandQueryWord(word string) string {
+	switch word {
+	case "AND", "OR", "NOT", "NEAR":
+		return word
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return word
+		}
+	}
+
+	form := normalizeTagForm(word)
+	for alias, canonical := range config.AppConfig.Tags.Synonyms {
+		if form != "" && normalizeTagForm(alias) == form {
+			canonical = normalizeTagForm(canonical)
+			return "(" + word + ` OR "` + strings.ReplaceAll(canonical, `"`, `""`) + `")`
+		}
+	}
+	return word
+}
+
+// tagPolicySignature describes the active tag policy so a change can trigger a re-index
+func tagPolicySignature() string {
+	policy := config.AppConfig.Tags
+
+	var synonyms []string
+	for alias, canonical := range policy.Synonyms {
+		synonyms = append(synonyms, alias+"="+canonical)
+	}
+	sort.Strings(synonyms)
+
+	return fmt.Sprintf("case_fold=%t;separator=%q;nfc=%t;synonyms=%s",
+		policy.CaseFold, policy.Separator, policy.NFC, strings.Join(synonyms, ","))
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sk25469/jot/config"
+)
+
+func withTagConfig(t *testing.T, tags config.TagConfig) {
+	t.Helper()
+	original := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = original })
+	config.AppConfig.Tags = tags
+}
+
+func TestNormalizeTag(t *testing.T) {
+	withTagConfig(t, config.TagConfig{
+		CaseFold:  true,
+		Separator: "-",
+		NFC:       true,
+		Synonyms:  map[string]string{"k8s": "Kubernetes"},
+	})
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"kafka", "kafka"},
+		{"Kafka", "kafka"},
+		{" kafka ", "kafka"},
+		{"follow up", "follow-up"},
+		{"follow_up", "follow-up"},
+		{"follow -- up", "follow-up"},
+		{"-kafka-", "kafka"},
+		{"café", "café"},
+		{"K8s", "kubernetes"},
+		{"   ", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			result := NormalizeTag(tc.input)
+			if result != tc.expected {
+				t.Errorf("NormalizeTag(%q) = %q, expected %q", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeTagPreservesCase(t *testing.T) {
+	withTagConfig(t, config.TagConfig{CaseFold: false, Separator: "_"})
+
+	result := NormalizeTag("Follow Up")
+	if result != "Follow_Up" {
+		t.Errorf("NormalizeTag() = %q, expected %q", result, "Follow_Up")
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	withTagConfig(t, config.TagConfig{
+		CaseFold:  true,
+		Separator: "-",
+		Synonyms:  map[string]string{"k8s": "kubernetes"},
+	})
+
+	result := NormalizeTags([]string{"Kafka", "kafka", " kafka", "", "k8s", "kubernetes"})
+	expected := []string{"kafka", "kubernetes"}
+
+	if len(result) != len(expected) {
+		t.Fatalf("NormalizeTags() = %v, expected %v", result, expected)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("NormalizeTags()[%d] = %q, expected %q", i, result[i], expected[i])
+		}
+	}
+}
+
+func TestExpandQuerySynonyms(t *testing.T) {
+	withTagConfig(t, config.TagConfig{
+		CaseFold:  true,
+		Separator: "-",
+		Synonyms:  map[string]string{"k8s": "kubernetes", "ml": "machine learning"},
+	})
+
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"K8s Upgrade", `(K8s OR "kubernetes") Upgrade`},
+		{"upgrade AND (k8s OR helm)", `upgrade AND ((k8s OR "kubernetes") OR helm)`},
+		{`"k8s upgrade" ml`, `"k8s upgrade" (ml OR "machine-learning")`},
+		{"k8s* ml-ops", "k8s* ml-ops"},
+		{"helm chart", "helm chart"},
+	}
+
+	for _, tc := range testCases {
+		if got := expandQuerySynonyms(tc.query); got != tc.expected {
+			t.Errorf("expandQuerySynonyms(%q) = %q, expected %q", tc.query, got, tc.expected)
+		}
+	}
+}
+
+func TestTagPolicySignature(t *testing.T) {
+	withTagConfig(t, config.TagConfig{CaseFold: true, Separator: "-"})
+	before := tagPolicySignature()
+
+	config.AppConfig.Tags.Synonyms = map[string]string{"k8s": "kubernetes"}
+	after := tagPolicySignature()
+
+	if before == after {
+		t.Errorf("Signature should change when synonyms change, got %q", after)
+	}
+}
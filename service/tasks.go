@@ -0,0 +1,232 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sk25469/jot/models"
+)
+
+var (
+	taskPattern     = regexp.MustCompile(`^(\s*[-*+]\s+)\[( |x|X)\](\s+.*)$`)
+	assigneePattern = regexp.MustCompile(`^@[\p{L}\p{N}_.-]*[\p{L}\p{N}_]$`)
+	taskWordPattern = regexp.MustCompile(`\S+`)
+)
+
+// taskPriorities maps the inline priority markers to their names
+var taskPriorities = map[string]string{
+	"!high":   "high",
+	"!medium": "medium",
+	"!low":    "low",
+}
+
+// parseTasks extracts the checkbox items from a note's body.
+// Task IDs depend on the note, the text and its occurrence, not on the
+// line number, done state or metadata, so they survive edits around them.
+func parseTasks(noteID, content string) []*models.Task {
+	var tasks []*models.Task
+	seen := make(map[string]int)
+
+	for _, line := range bodyLines(content) {
+		if line.InCode {
+			continue
+		}
+
+		match := taskPattern.FindStringSubmatch(strings.TrimRight(line.Text, "\r"))
+		if match == nil {
+			continue
+		}
+
+		task := &models.Task{
+			NoteID: noteID,
+			Line:   line.Number,
+			Done:   match[2] != " ",
+		}
+		task.Text = parseTaskMetadata(task, match[3])
+		if task.Text == "" {
+			continue
+		}
+
+		occurrence := seen[task.Text]
+		seen[task.Text]++
+		task.ID = generateShortID(fmt.Sprintf("%s:%s:%d", noteID, task.Text, occurrence))
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+// parseTaskMetadata fills in the due date, priority and assignee from the
+// inline markers such as due:2025-11-03, !high and @alice that end a task, and
+// returns the task text without them. Markers earlier in the text, repeated
+// ones and unrecognised ones are part of the text, which otherwise keeps its
+// spacing.
+func parseTaskMetadata(task *models.Task, text string) string {
+	words := taskWordPattern.FindAllStringIndex(text, -1)
+
+	start := len(words)
+	for start > 0 && applyTaskMarker(&models.Task{}, text[words[start-1][0]:words[start-1][1]]) {
+		start--
+	}
+	if start == len(words) {
+		return strings.TrimSpace(text)
+	}
+
+	kept := []string{strings.TrimSpace(text[:words[start][0]])}
+	for _, word := range words[start:] {
+		if marker := text[word[0]:word[1]]; !applyTaskMarker(task, marker) {
+			kept = append(kept, marker)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, " "))
+}
+
+// applyTaskMarker sets the task field a marker word stands for and reports
+// whether it did; a field that is already set is left alone
+func applyTaskMarker(task *models.Task, word string) bool {
+	lower := strings.ToLower(word)
+
+	if value, ok := strings.CutPrefix(lower, "due:"); ok && task.Due == nil {
+		if day, ok := parseDay(value); ok {
+			task.Due = &day
+			return true
+		}
+	}
+
+	if priority, ok := taskPriorities[lower]; ok && task.Priority == "" {
+		task.Priority = priority
+		return true
+	}
+
+	if assigneePattern.MatchString(word) && task.Assignee == "" {
+		task.Assignee = word[1:]
+		return true
+	}
+
+	return false
+}
+
+// ListTasks returns tasks across all notes with optional filtering
+func (s *NoteService) ListTasks(filter models.TaskFilter) ([]*models.Task, error) {
+	if len(filter.Tags) > 0 {
+		filter.Tags = NormalizeTags(filter.Tags)
+	}
+	if filter.Mode != "" {
+		filter.Mode = strings.ToLower(filter.Mode)
+	}
+	filter.Assignee = strings.TrimPrefix(filter.Assignee, "@")
+	return s.taskRepo.List(filter)
+}
+
+// CompleteTask ticks a task's checkbox in its note file and re-indexes the note
+func (s *NoteService) CompleteTask(identifier string) (*models.Task, error) {
+	task, err := s.findTask(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Done {
+		return nil, fmt.Errorf("task already done: %s", task.Text)
+	}
+
+	content, err := os.ReadFile(task.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	current, err := locateTask(task, string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	lines[current.Line-1] = taskPattern.ReplaceAllString(lines[current.Line-1], "${1}[x]${3}")
+
+	if err := os.WriteFile(task.FilePath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write note file: %w", err)
+	}
+
+	if err := s.syncNoteFromFile(task.FilePath, false); err != nil {
+		return nil, err
+	}
+
+	task.Done = true
+	task.Line = current.Line
+	return task, nil
+}
+
+// OpenTask opens a task's note in the editor with the cursor on the task
+func (s *NoteService) OpenTask(identifier string) (*models.Task, error) {
+	task, err := s.findTask(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(task.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	current, err := locateTask(task, string(content))
+	if err != nil {
+		return nil, err
+	}
+	task.Line = current.Line
+
+	if err := s.openInEditorAt(task.FilePath, task.NoteMode, task.Line); err != nil {
+		return nil, err
+	}
+	s.recordAccess(task.NoteID, AccessOpen)
+
+	return task, nil
+}
+
+// findTask resolves a full or unique partial task ID
+func (s *NoteService) findTask(identifier string) (*models.Task, error) {
+	tasks, err := s.taskRepo.FindByIDPrefix(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	// IDs are only unique within a note, so a full ID can still match tasks
+	// in several notes
+	var exact []*models.Task
+	for _, t := range tasks {
+		if t.ID == identifier {
+			exact = append(exact, t)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], nil
+	}
+	if len(exact) > 1 {
+		var notes []string
+		for _, t := range exact {
+			notes = append(notes, fmt.Sprintf("%q (%s)", t.NoteTitle, t.NoteID))
+		}
+		return nil, fmt.Errorf("task ID %s is in several notes: %s", identifier, strings.Join(notes, ", "))
+	}
+
+	switch len(tasks) {
+	case 0:
+		return nil, fmt.Errorf("task not found: %s", identifier)
+	case 1:
+		return tasks[0], nil
+	default:
+		return nil, fmt.Errorf("ambiguous task ID %s: matches %d tasks", identifier, len(tasks))
+	}
+}
+
+// locateTask finds a task in the current file content rather than trusting
+// the indexed line, in case the note was edited since the last sync
+func locateTask(task *models.Task, content string) (*models.Task, error) {
+	for _, t := range parseTasks(task.NoteID, content) {
+		if t.ID == task.ID {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("task no longer found in %s", task.FilePath)
+}
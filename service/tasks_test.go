@@ -0,0 +1,120 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestParseTasks(t *testing.T) {
+	content := "---\ntitle: Kafka incident\n---\n\n" +
+		"- [ ] follow up with infra\n" +
+		"* [x] restart consumers\n" +
+		"  - [X] nested item\n" +
+		"- [] not a task\n" +
+		"```\n- [ ] inside code\n```\n" +
+		"+ [ ] follow up with infra\n"
+
+	tasks := parseTasks("f4f1c39", content)
+	if len(tasks) != 4 {
+		t.Fatalf("parseTasks() returned %d tasks, expected 4", len(tasks))
+	}
+
+	expected := []struct {
+		line int
+		text string
+		done bool
+	}{
+		{5, "follow up with infra", false},
+		{6, "restart consumers", true},
+		{7, "nested item", true},
+		{12, "follow up with infra", false},
+	}
+	for i, exp := range expected {
+		task := tasks[i]
+		if task.Line != exp.line || task.Text != exp.text || task.Done != exp.done {
+			t.Errorf("Task %d = {%d %q %t}, expected {%d %q %t}",
+				i, task.Line, task.Text, task.Done, exp.line, exp.text, exp.done)
+		}
+		if task.NoteID != "f4f1c39" {
+			t.Errorf("Task %d has note ID %q, expected %q", i, task.NoteID, "f4f1c39")
+		}
+	}
+
+	if tasks[0].ID == tasks[3].ID {
+		t.Errorf("Duplicate task texts should get distinct IDs")
+	}
+}
+
+func TestParseTasksStableIDs(t *testing.T) {
+	before := parseTasks("f4f1c39", "- [ ] follow up with infra\n")
+	after := parseTasks("f4f1c39", "# Notes\n\nSome context.\n\n- [x] follow up with infra\n")
+
+	if before[0].ID != after[0].ID {
+		t.Errorf("Task ID changed after moving and ticking the task: %s != %s", before[0].ID, after[0].ID)
+	}
+
+	other := parseTasks("5f3f8ed", "- [ ] follow up with infra\n")
+	if before[0].ID == other[0].ID {
+		t.Errorf("Tasks in different notes should get distinct IDs")
+	}
+}
+
+func TestParseTaskMetadata(t *testing.T) {
+	content := "- [ ] follow up with infra due:2025-11-03 !high @alice\n" +
+		"- [ ] email bob@example.com about due:someday !urgent\n" +
+		"- [ ] review the design !LOW @alice @bob\n"
+
+	tasks := parseTasks("f4f1c39", content)
+	if len(tasks) != 3 {
+		t.Fatalf("parseTasks() returned %d tasks, expected 3", len(tasks))
+	}
+
+	first := tasks[0]
+	if first.Text != "follow up with infra" {
+		t.Errorf("Text = %q, expected markers to be stripped", first.Text)
+	}
+	if first.Due == nil || first.Due.Format("2006-01-02") != "2025-11-03" {
+		t.Errorf("Due = %v, expected 2025-11-03", first.Due)
+	}
+	if first.Priority != "high" || first.Assignee != "alice" {
+		t.Errorf("Priority/Assignee = %q/%q, expected high/alice", first.Priority, first.Assignee)
+	}
+
+	// Unrecognised markers and email addresses stay in the text
+	second := tasks[1]
+	if second.Text != "email bob@example.com about due:someday !urgent" {
+		t.Errorf("Text = %q, expected unrecognised markers to be kept", second.Text)
+	}
+	if second.Due != nil || second.Priority != "" || second.Assignee != "" {
+		t.Errorf("Expected no metadata, got due=%v priority=%q assignee=%q", second.Due, second.Priority, second.Assignee)
+	}
+
+	// Only the first assignee is taken
+	third := tasks[2]
+	if third.Priority != "low" || third.Assignee != "alice" || third.Text != "review the design @bob" {
+		t.Errorf("Got priority=%q assignee=%q text=%q", third.Priority, third.Assignee, third.Text)
+	}
+
+	// Markers only count at the end of the task, and the text keeps its spacing
+	testCases := []struct {
+		line     string
+		text     string
+		assignee string
+	}{
+		{"- [ ] ping @bob about the deploy", "ping @bob about the deploy", ""},
+		{"- [ ] run `make   test` @bob", "run `make   test`", "bob"},
+		{"- [ ] align the `a  |  b` table  ", "align the `a  |  b` table", ""},
+	}
+	for _, tc := range testCases {
+		task := parseTasks("f4f1c39", tc.line+"\n")[0]
+		if task.Text != tc.text || task.Assignee != tc.assignee {
+			t.Errorf("parseTasks(%q) = text %q assignee %q, expected %q %q",
+				tc.line, task.Text, task.Assignee, tc.text, tc.assignee)
+		}
+	}
+
+	// Changing the metadata keeps the task ID
+	rescheduled := parseTasks("f4f1c39", "- [ ] follow up with infra due:2025-11-10 @bob\n")
+	if rescheduled[0].ID != first.ID {
+		t.Errorf("Task ID changed after editing its metadata: %s != %s", rescheduled[0].ID, first.ID)
+	}
+}
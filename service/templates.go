@@ -0,0 +1,186 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sk25469/jot/config"
+	"github.com/sk25469/jot/models"
+)
+
+// TemplateData holds the variables available to note templates
+type TemplateData struct {
+	Title string
+	Mode  string
+	Tags  []string
+	Date  time.Time
+	User  string
+	Vars  map[string]string
+}
+
+// templateSkeleton is written by `jot template new`
+const templateSkeleton = `---
+tags: []
+---
+
+# {{.Title}}
+
+Created by {{.User}} on {{date "2006-01-02" .Date}}.
+
+`
+
+var templateFuncs = template.FuncMap{
+	"date":  func(layout string, t time.Time) string { return t.Format(layout) },
+	"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"now":   time.Now,
+}
+
+// ListTemplates returns the templates in the templates directory
+func (s *NoteService) ListTemplates() ([]*models.Template, error) {
+	files, err := filepath.Glob(filepath.Join(config.GetTemplatesDir(), "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan templates directory: %w", err)
+	}
+	sort.Strings(files)
+
+	var templates []*models.Template
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		templates = append(templates, &models.Template{
+			Name:      strings.TrimSuffix(filepath.Base(file), ".md"),
+			Path:      file,
+			UpdatedAt: info.ModTime(),
+		})
+	}
+
+	return templates, nil
+}
+
+// CreateTemplate writes a new template from the skeleton and opens it in the editor
+func (s *NoteService) CreateTemplate(name string) (*models.Template, error) {
+	path, err := templatePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("template already exists: %s", name)
+	}
+
+	if err := os.WriteFile(path, []byte(templateSkeleton), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write template: %w", err)
+	}
+
+	if err := s.openInEditor(path, ""); err != nil {
+		return nil, fmt.Errorf("failed to open editor: %w", err)
+	}
+
+	return &models.Template{Name: name, Path: path, UpdatedAt: time.Now()}, nil
+}
+
+// EditTemplate opens an existing template in the editor
+func (s *NoteService) EditTemplate(name string) error {
+	path, err := templatePath(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("template not found: %s", name)
+	}
+
+	return s.openInEditor(path, "")
+}
+
+// renderTemplate renders a named template with the given data
+func renderTemplate(name string, data TemplateData) (string, error) {
+	path, err := templatePath(name)
+	if err != nil {
+		return "", err
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("template not found: %s", name)
+		}
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+
+	return executeTemplate(name, string(source), data)
+}
+
+// executeTemplate parses and executes template source
+func executeTemplate(name, source string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).
+		Funcs(templateFuncs).
+		Option("missingkey=zero").
+		Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// splitTemplateOutput separates rendered template frontmatter from its body.
+// Standard fields are managed by jot, so only tags and extra fields are kept.
+func splitTemplateOutput(rendered string) (tags []string, extraFields []string, body string) {
+	body, start := splitFrontmatter(rendered)
+	if start == 0 {
+		return nil, nil, rendered
+	}
+
+	lines := strings.Split(rendered, "\n")
+	for _, line := range lines[1 : start-1] {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "title", "mode", "date":
+			continue
+		case "tags":
+			tags = append(tags, parseTagList(value)...)
+		default:
+			extraFields = append(extraFields, strings.TrimSpace(line))
+		}
+	}
+
+	return tags, extraFields, strings.TrimLeft(body, "\n")
+}
+
+// templatePath returns the file path for a template name
+func templatePath(name string) (string, error) {
+	name = strings.TrimSuffix(name, ".md")
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid template name: %q", name)
+	}
+	return filepath.Join(config.GetTemplatesDir(), name+".md"), nil
+}
+
+// currentUser returns the login name of the current user
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
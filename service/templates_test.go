@@ -0,0 +1,84 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	data := TemplateData{
+		Title: "API outage",
+		Mode:  "incident",
+		Tags:  []string{"api", "incident"},
+		Date:  time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		User:  "alice",
+		Vars:  map[string]string{"severity": "sev2"},
+	}
+
+	source := `# {{.Title}} ({{.Mode}})
+{{date "2006-01-02" .Date}} by {{.User}}
+tags: {{join ", " .Tags}}
+severity: {{.Vars.severity}} owner: {{.Vars.owner}}`
+
+	result, err := executeTemplate("test", source, data)
+	if err != nil {
+		t.Fatalf("executeTemplate() unexpected error: %v", err)
+	}
+
+	expected := `# API outage (incident)
+2025-03-14 by alice
+tags: api, incident
+severity: sev2 owner: `
+	if result != expected {
+		t.Errorf("executeTemplate() = %q, expected %q", result, expected)
+	}
+
+	if _, err := executeTemplate("broken", "{{.Title", data); err == nil {
+		t.Errorf("executeTemplate() should fail on invalid template syntax")
+	}
+}
+
+func TestSplitTemplateOutput(t *testing.T) {
+	rendered := `---
+title: ignored
+tags: [postmortem, api]
+severity: sev2
+---
+
+# Body`
+
+	tags, extraFields, body := splitTemplateOutput(rendered)
+
+	if !reflect.DeepEqual(tags, []string{"postmortem", " api"}) {
+		t.Errorf("tags = %q, expected [postmortem, api]", tags)
+	}
+	if !reflect.DeepEqual(extraFields, []string{"severity: sev2"}) {
+		t.Errorf("extraFields = %v, expected [severity: sev2]", extraFields)
+	}
+	if body != "# Body" {
+		t.Errorf("body = %q, expected %q", body, "# Body")
+	}
+
+	tags, extraFields, body = splitTemplateOutput("# No frontmatter")
+	if tags != nil || extraFields != nil || body != "# No frontmatter" {
+		t.Errorf("Output without frontmatter should be returned as body, got %v %v %q", tags, extraFields, body)
+	}
+}
+
+func TestTemplatePath(t *testing.T) {
+	path, err := templatePath("incident")
+	if err != nil {
+		t.Fatalf("templatePath() unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(path, "templates/incident.md") {
+		t.Errorf("templatePath() = %q, expected it to end with templates/incident.md", path)
+	}
+
+	for _, name := range []string{"", "../secrets", ".hidden", "a/b"} {
+		if _, err := templatePath(name); err == nil {
+			t.Errorf("templatePath(%q) should be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package styles
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// codeLanguage describes just enough of a language to colour it line by line
+type codeLanguage struct {
+	keywords      map[string]bool
+	lineComments  []string
+	quotes        string // Characters that open and close strings
+	keysBeforeTag bool   // Colour "key:" at the start of a line, as in YAML
+}
+
+func keywordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+var (
+	goLanguage = codeLanguage{
+		keywords: keywordSet(`break case chan const continue default defer else fallthrough for func go
+			goto if import interface map package range return select struct switch type var
+			nil true false iota error string int int64 bool byte rune float64 any`),
+		lineComments: []string{"//"},
+		quotes:       "\"'`",
+	}
+	pythonLanguage = codeLanguage{
+		keywords: keywordSet(`and as assert async await break class continue def del elif else except
+			finally for from global if import in is lambda nonlocal not or pass raise return
+			try while with yield None True False self`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}
+	jsLanguage = codeLanguage{
+		keywords: keywordSet(`async await break case catch class const continue default delete do else
+			export extends finally for from function if import in instanceof interface let new
+			of return switch this throw try type typeof var void while yield null undefined true false`),
+		lineComments: []string{"//"},
+		quotes:       "\"'`",
+	}
+	shellLanguage = codeLanguage{
+		keywords: keywordSet(`if then else elif fi for while until do done case esac in function return
+			export local readonly set unset echo exit`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}
+	sqlLanguage = codeLanguage{
+		keywords: keywordSet(`select from where join left right inner outer on and or not in is null as
+			insert into values update set delete create table index view drop alter add primary key
+			foreign references group by order having limit offset distinct union all case when then
+			else end begin commit rollback with exists like between asc desc
+			SELECT FROM WHERE JOIN LEFT RIGHT INNER OUTER ON AND OR NOT IN IS NULL AS
+			INSERT INTO VALUES UPDATE SET DELETE CREATE TABLE INDEX VIEW DROP ALTER ADD PRIMARY KEY
+			FOREIGN REFERENCES GROUP BY ORDER HAVING LIMIT OFFSET DISTINCT UNION ALL CASE WHEN THEN
+			ELSE END BEGIN COMMIT ROLLBACK WITH EXISTS LIKE BETWEEN ASC DESC`),
+		lineComments: []string{"--"},
+		quotes:       "'\"",
+	}
+	yamlLanguage = codeLanguage{
+		keywords:      keywordSet("true false null yes no"),
+		lineComments:  []string{"#"},
+		quotes:        "\"'",
+		keysBeforeTag: true,
+	}
+	jsonLanguage = codeLanguage{
+		keywords: keywordSet("true false null"),
+		quotes:   "\"",
+	}
+	plainLanguage = codeLanguage{
+		quotes: "\"'",
+	}
+)
+
+// codeLanguages maps fence info strings to languages
+var codeLanguages = map[string]codeLanguage{
+	"go":         goLanguage,
+	"golang":     goLanguage,
+	"python":     pythonLanguage,
+	"py":         pythonLanguage,
+	"javascript": jsLanguage,
+	"js":         jsLanguage,
+	"typescript": jsLanguage,
+	"ts":         jsLanguage,
+	"sh":         shellLanguage,
+	"bash":       shellLanguage,
+	"shell":      shellLanguage,
+	"zsh":        shellLanguage,
+	"console":    shellLanguage,
+	"sql":        sqlLanguage,
+	"yaml":       yamlLanguage,
+	"yml":        yamlLanguage,
+	"json":       jsonLanguage,
+}
+
+var (
+	codeKeywordStyle = lipgloss.NewStyle().Foreground(Secondary).Bold(true)
+	codeStringStyle  = lipgloss.NewStyle().Foreground(Success)
+	codeNumberStyle  = lipgloss.NewStyle().Foreground(Accent)
+	codeCommentStyle = lipgloss.NewStyle().Foreground(Subtle).Italic(true)
+	codeKeyStyle     = lipgloss.NewStyle().Foreground(Primary)
+	codeTextStyle    = lipgloss.NewStyle().Foreground(Text)
+)
+
+// HighlightCode colours one line of code in a fenced block's language.
+// Unknown languages only get strings and numbers coloured.
+func HighlightCode(line, lang string) string {
+	language, ok := codeLanguages[strings.ToLower(lang)]
+	if !ok {
+		language = plainLanguage
+	}
+
+	var b strings.Builder
+	runes := []rune(line)
+	i := 0
+
+	if language.keysBeforeTag {
+		trimmed := strings.TrimLeft(line, " -")
+		if key, _, found := strings.Cut(trimmed, ":"); found && key != "" && !strings.ContainsAny(key, " \"'#") {
+			start := len([]rune(line)) - len([]rune(trimmed))
+			b.WriteString(string(runes[:start]))
+			b.WriteString(codeKeyStyle.Render(key))
+			i = start + len([]rune(key))
+		}
+	}
+
+	for i < len(runes) {
+		r := runes[i]
+		rest := string(runes[i:])
+
+		switch {
+		case hasAnyPrefix(rest, language.lineComments):
+			b.WriteString(codeCommentStyle.Render(rest))
+			return b.String()
+
+		case strings.ContainsRune(language.quotes, r):
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(runes))
+			b.WriteString(codeStringStyle.Render(string(runes[i:end])))
+			i = end
+
+		case unicode.IsDigit(r):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == '_' || unicode.IsLetter(runes[end])) {
+				end++
+			}
+			b.WriteString(codeNumberStyle.Render(string(runes[i:end])))
+			i = end
+
+		case unicode.IsLetter(r) || r == '_':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			word := string(runes[i:end])
+			if language.keywords[word] {
+				b.WriteString(codeKeywordStyle.Render(word))
+			} else {
+				b.WriteString(codeTextStyle.Render(word))
+			}
+			i = end
+
+		default:
+			b.WriteString(codeTextStyle.Render(string(r)))
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
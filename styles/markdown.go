@@ -0,0 +1,321 @@
+package styles
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+var (
+	mdHeadingPattern  = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.+?)(?:[ \t]+#+)?[ \t]*$`)
+	mdRulePattern     = regexp.MustCompile(`^ {0,3}(?:-(?:[ \t]*-){2,}|\*(?:[ \t]*\*){2,}|_(?:[ \t]*_){2,})[ \t]*$`)
+	mdListPattern     = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdTaskPattern     = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	mdTableSepPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+	mdCodeSpanPattern  = regexp.MustCompile("`[^`]+`")
+	mdImagePattern     = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdLinkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdWikiLinkPattern  = regexp.MustCompile(`\[\[([^\[\]|]+)(?:\|([^\[\]]*))?\]\]`)
+	mdBoldPattern      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalicPattern    = regexp.MustCompile(`(^|[^\pL\pN*_])(?:\*([^*\s][^*]*)\*|_([^_\s][^_]*)_)`)
+	mdStrikePattern    = regexp.MustCompile(`~~([^~]+)~~`)
+	mdHashtagPattern   = regexp.MustCompile(`(^|\s)(#[\pL\pN_][\pL\pN_/-]*)`)
+	mdTableCellTrimmer = strings.NewReplacer(`\|`, "|")
+)
+
+var (
+	mdHeadingStyles = []lipgloss.Style{
+		lipgloss.NewStyle().Bold(true).Foreground(Primary),
+		lipgloss.NewStyle().Bold(true).Foreground(Secondary),
+		lipgloss.NewStyle().Bold(true).Foreground(Accent),
+		lipgloss.NewStyle().Bold(true).Foreground(Text),
+	}
+	mdCodeSpanStyle  = lipgloss.NewStyle().Foreground(Accent)
+	mdLinkStyle      = lipgloss.NewStyle().Foreground(Secondary).Underline(true)
+	mdURLStyle       = lipgloss.NewStyle().Foreground(Subtle)
+	mdQuoteStyle     = lipgloss.NewStyle().Foreground(Subtle).Italic(true)
+	mdBulletStyle    = lipgloss.NewStyle().Foreground(Primary)
+	mdDoneStyle      = lipgloss.NewStyle().Foreground(Success)
+	mdDoneTextStyle  = lipgloss.NewStyle().Foreground(Subtle).Strikethrough(true)
+	mdHashtagStyle   = lipgloss.NewStyle().Foreground(Primary)
+	mdBorderStyle    = lipgloss.NewStyle().Foreground(Muted)
+	mdCodeLabelStyle = lipgloss.NewStyle().Foreground(Subtle).Italic(true)
+)
+
+// RenderMarkdown renders a Markdown body for the terminal with the palette:
+// headings, emphasis, lists and tasks, quotes, rules, tables, links and fenced
+// code with syntax colouring. Text is wrapped to width, or not at all when
+// width is 0.
+func RenderMarkdown(body string, width int) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	var out []string
+	blank := func() {
+		if len(out) > 0 && out[len(out)-1] != "" {
+			out = append(out, "")
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			blank()
+
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence := trimmed[:3]
+			lang := strings.Fields(strings.TrimLeft(trimmed, fence[:1]) + " ")
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			label := ""
+			if len(lang) > 0 {
+				label = lang[0]
+			}
+			out = append(out, renderCodeBlock(code, label)...)
+
+		case mdHeadingPattern.MatchString(line):
+			blank()
+			match := mdHeadingPattern.FindStringSubmatch(line)
+			level := len(match[1])
+			style := mdHeadingStyles[min(level, len(mdHeadingStyles))-1]
+			text := style.Render(renderInline(match[2]))
+			out = append(out, wrap(text, width))
+			if level == 1 {
+				out = append(out, style.Render(strings.Repeat("═", min(max(runewidth.StringWidth(match[2]), 3), ruleWidth(width)))))
+			}
+			out = append(out, "")
+
+		case mdRulePattern.MatchString(line):
+			out = append(out, mdBorderStyle.Render(strings.Repeat("─", ruleWidth(width))))
+
+		case strings.Contains(line, "|") && i+1 < len(lines) && mdTableSepPattern.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"):
+			rows := [][]string{splitTableRow(line)}
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+				rows = append(rows, splitTableRow(lines[i]))
+			}
+			i--
+			out = append(out, renderTable(rows)...)
+
+		case strings.HasPrefix(trimmed, ">"):
+			text := strings.TrimSpace(strings.TrimLeft(trimmed, "> "))
+			quoted := wrap(mdQuoteStyle.Render(renderInline(text)), max(width-2, 0))
+			for _, l := range strings.Split(quoted, "\n") {
+				out = append(out, mdBorderStyle.Render("│ ")+l)
+			}
+
+		case mdListPattern.MatchString(line):
+			match := mdListPattern.FindStringSubmatch(line)
+			indent := strings.Repeat(" ", runewidth.StringWidth(strings.ReplaceAll(match[1], "\t", "  ")))
+			marker, text := match[2], match[3]
+
+			switch {
+			case mdTaskPattern.MatchString(text):
+				task := mdTaskPattern.FindStringSubmatch(text)
+				if task[1] == " " {
+					marker, text = mdBulletStyle.Render("☐"), renderInline(task[2])
+				} else {
+					marker, text = mdDoneStyle.Render("☑"), mdDoneTextStyle.Render(ansi.Strip(renderInline(task[2])))
+				}
+			case strings.ContainsAny(marker, "-*+"):
+				marker, text = mdBulletStyle.Render("•"), renderInline(text)
+			default:
+				marker, text = mdBulletStyle.Render(marker), renderInline(text)
+			}
+
+			prefix := indent + marker + " "
+			hang := strings.Repeat(" ", ansi.StringWidth(prefix))
+			for j, l := range strings.Split(wrap(text, max(width-len(hang), 0)), "\n") {
+				if j == 0 {
+					out = append(out, prefix+l)
+				} else {
+					out = append(out, hang+l)
+				}
+			}
+
+		default:
+			// Consecutive text lines form one paragraph
+			paragraph := []string{trimmed}
+			for i+1 < len(lines) && isParagraphLine(lines[i+1], lines, i+1) {
+				i++
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			out = append(out, wrap(renderInline(strings.Join(paragraph, " ")), width))
+		}
+	}
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+// isParagraphLine reports whether a line continues a paragraph rather than
+// starting another block
+func isParagraphLine(line string, lines []string, i int) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" ||
+		strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") ||
+		strings.HasPrefix(trimmed, ">") ||
+		mdHeadingPattern.MatchString(line) || mdRulePattern.MatchString(line) || mdListPattern.MatchString(line) {
+		return false
+	}
+	return !(strings.Contains(line, "|") && i+1 < len(lines) && mdTableSepPattern.MatchString(lines[i+1]))
+}
+
+// renderInline styles code spans, links, emphasis and #tags within a line.
+// Code spans are rendered as-is, without styling what is inside them.
+func renderInline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range mdCodeSpanPattern.FindAllStringIndex(text, -1) {
+		b.WriteString(renderSpans(text[last:loc[0]]))
+		b.WriteString(mdCodeSpanStyle.Render(text[loc[0]+1 : loc[1]-1]))
+		last = loc[1]
+	}
+	b.WriteString(renderSpans(text[last:]))
+	return b.String()
+}
+
+func renderSpans(text string) string {
+	text = mdImagePattern.ReplaceAllStringFunc(text, func(m string) string {
+		match := mdImagePattern.FindStringSubmatch(m)
+		alt := match[1]
+		if alt == "" {
+			alt = "image"
+		}
+		return mdLinkStyle.Render("🖼 "+alt) + mdURLStyle.Render(" ("+match[2]+")")
+	})
+	text = mdLinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		match := mdLinkPattern.FindStringSubmatch(m)
+		if match[1] == match[2] {
+			return mdLinkStyle.Render(match[1])
+		}
+		return mdLinkStyle.Render(match[1]) + mdURLStyle.Render(" ("+match[2]+")")
+	})
+	text = mdWikiLinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		match := mdWikiLinkPattern.FindStringSubmatch(m)
+		if alias := strings.TrimSpace(match[2]); alias != "" {
+			return mdLinkStyle.Render(alias)
+		}
+		return mdLinkStyle.Render(strings.TrimSpace(match[1]))
+	})
+	text = mdBoldPattern.ReplaceAllStringFunc(text, func(m string) string {
+		match := mdBoldPattern.FindStringSubmatch(m)
+		return lipgloss.NewStyle().Bold(true).Render(match[1] + match[2])
+	})
+	text = mdItalicPattern.ReplaceAllStringFunc(text, func(m string) string {
+		match := mdItalicPattern.FindStringSubmatch(m)
+		return match[1] + lipgloss.NewStyle().Italic(true).Render(match[2]+match[3])
+	})
+	text = mdStrikePattern.ReplaceAllStringFunc(text, func(m string) string {
+		return lipgloss.NewStyle().Strikethrough(true).Render(mdStrikePattern.FindStringSubmatch(m)[1])
+	})
+	return mdHashtagPattern.ReplaceAllStringFunc(text, func(m string) string {
+		match := mdHashtagPattern.FindStringSubmatch(m)
+		return match[1] + mdHashtagStyle.Render(match[2])
+	})
+}
+
+// renderCodeBlock colours the lines of a fenced code block behind a gutter
+func renderCodeBlock(code []string, lang string) []string {
+	gutter := mdBorderStyle.Render("│ ")
+	var out []string
+	if lang != "" {
+		out = append(out, mdBorderStyle.Render("╭ ")+mdCodeLabelStyle.Render(lang))
+	}
+	for _, line := range code {
+		out = append(out, gutter+HighlightCode(strings.ReplaceAll(line, "\t", "    "), lang))
+	}
+	return append(out, "")
+}
+
+// splitTableRow splits a pipe table row into trimmed cells
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	start := 0
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, mdTableCellTrimmer.Replace(strings.TrimSpace(line[start:i])))
+			start = i + 1
+		}
+	}
+	return append(cells, mdTableCellTrimmer.Replace(strings.TrimSpace(line[start:])))
+}
+
+// renderTable aligns a pipe table's rows, the first being the header
+func renderTable(rows [][]string) []string {
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	rendered := make([][]string, len(rows))
+	widths := make([]int, columns)
+	for r, row := range rows {
+		rendered[r] = make([]string, columns)
+		for c := 0; c < columns; c++ {
+			cell := ""
+			if c < len(row) {
+				cell = renderInline(row[c])
+			}
+			if r == 0 {
+				cell = lipgloss.NewStyle().Bold(true).Foreground(Primary).Render(ansi.Strip(cell))
+			}
+			rendered[r][c] = cell
+			widths[c] = max(widths[c], ansi.StringWidth(cell))
+		}
+	}
+
+	separator := mdBorderStyle.Render(" │ ")
+	var out []string
+	for r, row := range rendered {
+		cells := make([]string, columns)
+		for c, cell := range row {
+			cells[c] = cell + strings.Repeat(" ", widths[c]-ansi.StringWidth(cell))
+		}
+		out = append(out, strings.TrimRight(strings.Join(cells, separator), " "))
+
+		if r == 0 {
+			rules := make([]string, columns)
+			for c, w := range widths {
+				rules[c] = strings.Repeat("─", w)
+			}
+			out = append(out, mdBorderStyle.Render(strings.Join(rules, "─┼─")))
+		}
+	}
+	return append(out, "")
+}
+
+// wrap word-wraps styled text to a width, leaving it alone for width 0
+func wrap(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	return ansi.Wrap(text, width, "")
+}
+
+// ruleWidth is the width of horizontal rules, capped for wide terminals
+func ruleWidth(width int) int {
+	if width <= 0 || width > 80 {
+		return 80
+	}
+	return width
+}
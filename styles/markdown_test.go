@@ -0,0 +1,92 @@
+package styles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		width    int
+		expected string
+	}{
+		{
+			name:     "heading with underline",
+			body:     "# Title\n\ntext",
+			expected: "Title\n═════\n\ntext",
+		},
+		{
+			name:     "paragraph lines are joined and wrapped",
+			body:     "one two\nthree four five",
+			width:    10,
+			expected: "one two\nthree four\nfive",
+		},
+		{
+			name:     "inline markup is removed",
+			body:     "some **bold**, *em*, `a*b*c` and [site](https://x.io)",
+			expected: "some bold, em, a*b*c and site (https://x.io)",
+		},
+		{
+			name:     "wiki links show their alias",
+			body:     "see [[Kafka notes|kafka]] and [[Other]]",
+			expected: "see kafka and Other",
+		},
+		{
+			name:     "lists and tasks",
+			body:     "- item\n- [ ] open\n- [x] done\n  2. nested",
+			expected: "• item\n☐ open\n☑ done\n  2. nested",
+		},
+		{
+			name:     "quote and rule",
+			body:     "> quoted\n\n***",
+			width:    10,
+			expected: "│ quoted\n\n──────────",
+		},
+		{
+			name:     "table columns are aligned",
+			body:     "| a | long header |\n|---|---|\n| wide cell | x |",
+			expected: "a         │ long header\n──────────┼────────────\nwide cell │ x",
+		},
+		{
+			name:     "code block keeps its lines",
+			body:     "```go\nx := 1 // one\n```",
+			expected: "╭ go\n│ x := 1 // one",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ansi.Strip(RenderMarkdown(tc.body, tc.width))
+			if result != tc.expected {
+				t.Errorf("RenderMarkdown(%q) =\n%s\nexpected\n%s", tc.body, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHighlightCode(t *testing.T) {
+	testCases := []struct {
+		line string
+		lang string
+	}{
+		{`fmt.Println("hi", 42) // done`, "go"},
+		{`SELECT * FROM notes WHERE id = 'x' -- one`, "sql"},
+		{`key: "value" # comment`, "yaml"},
+		{`echo "it's" 3`, "unknown"},
+		{`"unterminated`, "python"},
+	}
+
+	for _, tc := range testCases {
+		result := HighlightCode(tc.line, tc.lang)
+		if stripped := ansi.Strip(result); stripped != tc.line {
+			t.Errorf("HighlightCode(%q, %q) changed the text to %q", tc.line, tc.lang, stripped)
+		}
+		if strings.Contains(tc.line, "//") && !strings.Contains(result, "// done") {
+			t.Errorf("HighlightCode(%q, %q) should keep the comment in one span", tc.line, tc.lang)
+		}
+	}
+}
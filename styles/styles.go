@@ -4,8 +4,10 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 // Color palette
@@ -146,38 +148,93 @@ func GetTagStyle(tagName string) lipgloss.Style {
 		MarginRight(1)
 }
 
+// Mode colors - built-in modes, extended by the mode registry
+var modeColors = map[string]lipgloss.Color{
+	"dev":     Primary,
+	"journal": Secondary,
+	"meeting": Accent,
+}
+
+// SetModeColor registers the badge color for a mode
+func SetModeColor(mode string, color lipgloss.Color) {
+	modeColors[mode] = color
+}
+
+// ModeColor returns the badge color of a mode, Muted for unknown modes
+func ModeColor(mode string) lipgloss.Color {
+	if color, ok := modeColors[mode]; ok {
+		return color
+	}
+	return Muted
+}
+
 // GetModeStyle returns a style for note modes
 func GetModeStyle(mode string) lipgloss.Style {
-	switch mode {
-	case "dev":
-		return lipgloss.NewStyle().
-			Background(Primary).
-			Foreground(lipgloss.Color("#282A36")).
-			Bold(true).
-			Padding(0, 1)
-	case "journal":
-		return lipgloss.NewStyle().
-			Background(Secondary).
-			Foreground(lipgloss.Color("#F8F8F2")).
-			Bold(true).
-			Padding(0, 1)
-	case "meeting":
-		return lipgloss.NewStyle().
-			Background(Accent).
-			Foreground(lipgloss.Color("#282A36")).
-			Bold(true).
-			Padding(0, 1)
-	default:
+	background, ok := modeColors[mode]
+	if !ok {
 		return lipgloss.NewStyle().
 			Background(Muted).
 			Foreground(Text).
 			Bold(true).
 			Padding(0, 1)
 	}
+
+	return lipgloss.NewStyle().
+		Background(background).
+		Foreground(readableForeground(background)).
+		Bold(true).
+		Padding(0, 1)
+}
+
+// readableForeground picks light or dark text for a background color
+func readableForeground(background lipgloss.Color) lipgloss.Color {
+	var r, g, b int
+	if _, err := fmt.Sscanf(string(background), "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return lipgloss.Color("#282A36")
+	}
+
+	luminance := 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+	if luminance < 128 {
+		return lipgloss.Color("#F8F8F2")
+	}
+	return lipgloss.Color("#282A36")
+}
+
+// namedColors exposes the palette by name, e.g. for output templates
+var namedColors = map[string]lipgloss.Color{
+	"primary":   Primary,
+	"secondary": Secondary,
+	"accent":    Accent,
+	"success":   Success,
+	"warning":   Warning,
+	"error":     Error,
+	"subtle":    Subtle,
+	"muted":     Muted,
+	"text":      Text,
+}
+
+// NamedColor looks up a palette color by name ("accent", "error", ...) or
+// accepts a "#RRGGBB" hex color
+func NamedColor(name string) (lipgloss.Color, bool) {
+	if strings.HasPrefix(name, "#") {
+		return lipgloss.Color(name), true
+	}
+	color, ok := namedColors[strings.ToLower(name)]
+	return color, ok
 }
 
 // Utility functions
 
+// Truncate shortens text to at most width terminal cells, ending it with "…"
+// when cut. Wide characters such as CJK and emoji count as two cells and are
+// never split.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return runewidth.Truncate(s, width, "…")
+}
+
 // RenderTags renders a slice of tags with consistent colors
 func RenderTags(tags []string) string {
 	if len(tags) == 0 {
@@ -299,3 +299,39 @@ func TestStyleDefinitions(t *testing.T) {
 		})
 	}
 }
+
+func TestSetModeColor(t *testing.T) {
+	defer delete(modeColors, "incident")
+
+	before := GetModeStyle("incident").GetBackground()
+	SetModeColor("incident", lipgloss.Color("#FF5555"))
+	after := GetModeStyle("incident").GetBackground()
+
+	if before == after {
+		t.Errorf("Registered mode color should change the badge background")
+	}
+	if after != lipgloss.Color("#FF5555") {
+		t.Errorf("Expected background #FF5555, got %v", after)
+	}
+}
+
+func TestReadableForeground(t *testing.T) {
+	testCases := []struct {
+		background lipgloss.Color
+		expected   lipgloss.Color
+	}{
+		{Primary, lipgloss.Color("#282A36")},
+		{Secondary, lipgloss.Color("#F8F8F2")},
+		{Accent, lipgloss.Color("#282A36")},
+		{lipgloss.Color("5"), lipgloss.Color("#282A36")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.background), func(t *testing.T) {
+			result := readableForeground(tc.background)
+			if result != tc.expected {
+				t.Errorf("readableForeground(%s) = %s, expected %s", tc.background, result, tc.expected)
+			}
+		})
+	}
+}
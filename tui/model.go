@@ -0,0 +1,541 @@
+// Package tui implements jot's full-screen interface on top of the same
+// NoteService the commands use.
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
+)
+
+// Run starts the interface and returns when the user quits
+func Run(notes *service.NoteService) error {
+	m := newModel(notes)
+	if err := m.reload(); err != nil {
+		return err
+	}
+
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+type focusArea int
+
+const (
+	focusList focusArea = iota
+	focusSidebar
+)
+
+// inputKind is what the text typed at the bottom of the screen is for
+type inputKind int
+
+const (
+	inputNone inputKind = iota
+	inputFilter
+	inputSearch
+	inputRetag
+	inputCreate
+	inputConfirmDelete
+)
+
+// sidebarItem is an entry of the mode and tag sidebars. The empty kind is
+// the entry for all notes.
+type sidebarItem struct {
+	kind  string // "", "mode" or "tag"
+	value string
+	count int
+}
+
+func (item sidebarItem) matches(note *models.Note) bool {
+	switch item.kind {
+	case "mode":
+		return note.Mode == item.value
+	case "tag":
+		for _, tag := range note.Tags {
+			if tag == item.value {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// preview is the rendered body of the note under the cursor, kept until the
+// note changes or the pane is resized
+type preview struct {
+	id      string
+	updated time.Time
+	width   int
+	lines   []string
+}
+
+type model struct {
+	service *service.NoteService
+
+	notes   []*models.Note // Every note, newest first
+	visible []*models.Note // Notes left after the sidebar, search and filter
+	sidebar []sidebarItem
+	scope   sidebarItem // Sidebar entry the list is narrowed to
+
+	focus      focusArea
+	cursor     int
+	offset     int // First note shown in the list
+	sideCursor int
+
+	input     inputKind
+	buffer    []rune
+	filter    string         // Fuzzy filter over titles, tags and IDs
+	search    string         // Full-text query
+	searchHit map[string]int // Rank of each note the full-text query found
+
+	preview       preview
+	previewScroll int
+
+	status        string
+	statusIsError bool
+	width, height int
+}
+
+func newModel(notes *service.NoteService) *model {
+	return &model{service: notes, width: 80, height: 24}
+}
+
+// reload reads the notes from the index again, keeping the cursor on the
+// same note when it still exists
+func (m *model) reload() error {
+	notes, err := m.service.ListNotes("", "", models.ExcludeArchived)
+	if err != nil {
+		return err
+	}
+	m.setNotes(notes)
+	if m.search != "" {
+		m.runSearch()
+	}
+	return nil
+}
+
+func (m *model) setNotes(notes []*models.Note) {
+	current := m.selected()
+	m.notes = notes
+	m.sidebar = buildSidebar(notes)
+	m.sideCursor = min(m.sideCursor, len(m.sidebar)-1)
+	m.applyFilters()
+	if current != nil {
+		m.selectID(current.ID)
+	}
+}
+
+// buildSidebar lists all notes, then every mode and every tag with their
+// note counts, most used first
+func buildSidebar(notes []*models.Note) []sidebarItem {
+	modes := make(map[string]int)
+	tags := make(map[string]int)
+	for _, note := range notes {
+		modes[note.Mode]++
+		for _, tag := range note.Tags {
+			tags[tag]++
+		}
+	}
+
+	items := []sidebarItem{{count: len(notes)}}
+	items = append(items, sortedItems("mode", modes)...)
+	return append(items, sortedItems("tag", tags)...)
+}
+
+func sortedItems(kind string, counts map[string]int) []sidebarItem {
+	items := make([]sidebarItem, 0, len(counts))
+	for value, count := range counts {
+		items = append(items, sidebarItem{kind: kind, value: value, count: count})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].count != items[j].count {
+			return items[i].count > items[j].count
+		}
+		return items[i].value < items[j].value
+	})
+	return items
+}
+
+// applyFilters narrows the notes to the sidebar entry, the full-text
+// results in rank order and the fuzzy filter, in that order
+func (m *model) applyFilters() {
+	var notes []*models.Note
+	for _, note := range m.notes {
+		if !m.scope.matches(note) {
+			continue
+		}
+		if _, ok := m.searchHit[note.ID]; m.searchHit != nil && !ok {
+			continue
+		}
+		notes = append(notes, note)
+	}
+
+	if m.searchHit != nil {
+		sort.SliceStable(notes, func(i, j int) bool {
+			return m.searchHit[notes[i].ID] < m.searchHit[notes[j].ID]
+		})
+	}
+
+	m.visible = service.FilterNotes(m.filter, notes)
+	m.cursor = max(0, min(m.cursor, len(m.visible)-1))
+}
+
+// runSearch runs the full-text query. A query FTS can't parse yet, like one
+// with an open quote, keeps the previous results.
+func (m *model) runSearch() {
+	if strings.TrimSpace(m.search) == "" {
+		m.searchHit = nil
+		m.applyFilters()
+		return
+	}
+
+	results, err := m.service.SearchNotes(m.search, models.ExcludeArchived)
+	if err != nil {
+		return
+	}
+	m.searchHit = make(map[string]int, len(results))
+	for i, result := range results {
+		if _, ok := m.searchHit[result.ID]; !ok {
+			m.searchHit[result.ID] = i
+		}
+	}
+	m.cursor = 0
+	m.applyFilters()
+}
+
+func (m *model) selected() *models.Note {
+	if m.cursor < len(m.visible) {
+		return m.visible[m.cursor]
+	}
+	return nil
+}
+
+func (m *model) selectID(id string) {
+	for i, note := range m.visible {
+		if note.ID == id {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+func (m *model) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.visible)-1))
+	m.previewScroll = 0
+}
+
+func (m *model) setStatus(format string, args ...any) {
+	m.status, m.statusIsError = fmt.Sprintf(format, args...), false
+}
+
+func (m *model) setError(err error) {
+	m.status, m.statusIsError = err.Error(), true
+}
+
+// editorFinishedMsg is sent when the editor opened for a note exits
+type editorFinishedMsg struct {
+	noteID string
+	err    error
+}
+
+// editorCommand runs the editor through a NoteService call while the
+// interface has released the terminal
+type editorCommand struct {
+	run func() (string, error)
+	id  string
+}
+
+func (c *editorCommand) Run() error {
+	id, err := c.run()
+	c.id = id
+	return err
+}
+
+func (c *editorCommand) SetStdin(io.Reader)  {}
+func (c *editorCommand) SetStdout(io.Writer) {}
+func (c *editorCommand) SetStderr(io.Writer) {}
+
+func (m *model) runEditor(run func() (string, error)) tea.Cmd {
+	command := &editorCommand{run: run}
+	return tea.Exec(command, func(err error) tea.Msg {
+		return editorFinishedMsg{noteID: command.id, err: err}
+	})
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case editorFinishedMsg:
+		if msg.err != nil {
+			m.setError(msg.err)
+		}
+		// The note may have changed on disk, so index it again
+		if err := m.service.SyncFromFileSystem(); err != nil {
+			m.setError(err)
+		}
+		if err := m.reload(); err != nil {
+			m.setError(err)
+		}
+		if msg.noteID != "" {
+			m.selectID(msg.noteID)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.input != inputNone {
+			return m, m.handleInputKey(msg)
+		}
+		if m.focus == focusSidebar {
+			return m, m.handleSidebarKey(msg)
+		}
+		return m, m.handleListKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m *model) handleListKey(msg tea.KeyMsg) tea.Cmd {
+	page := max(m.bodyHeight()-1, 1)
+
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-page)
+	case "pgdown":
+		m.move(page)
+	case "home", "g":
+		m.move(-len(m.visible))
+	case "end", "G":
+		m.move(len(m.visible))
+	case "ctrl+u":
+		m.previewScroll = max(m.previewScroll-page/2, 0)
+	case "ctrl+d":
+		m.previewScroll += page / 2
+	case "tab":
+		if m.showSidebar() {
+			m.focus = focusSidebar
+		}
+	case "/":
+		m.startInput(inputFilter, m.filter)
+	case "?":
+		m.startInput(inputSearch, m.search)
+	case "esc":
+		m.filter, m.search, m.searchHit, m.scope = "", "", nil, sidebarItem{}
+		m.applyFilters()
+	case "enter", "e":
+		if note := m.selected(); note != nil {
+			return m.runEditor(func() (string, error) {
+				return note.ID, m.service.OpenNoteAt(note, 0)
+			})
+		}
+	case "n":
+		m.startInput(inputCreate, "")
+	case "t":
+		if note := m.selected(); note != nil {
+			m.startInput(inputRetag, strings.Join(frontmatterTags(note), ", "))
+		}
+	case "d":
+		if m.selected() != nil {
+			m.startInput(inputConfirmDelete, "")
+		}
+	case "r":
+		if err := m.service.SyncFromFileSystem(); err != nil {
+			m.setError(err)
+		} else if err := m.reload(); err != nil {
+			m.setError(err)
+		} else {
+			m.setStatus("Reloaded %d notes", len(m.notes))
+		}
+	}
+	return nil
+}
+
+func (m *model) handleSidebarKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "up", "k":
+		m.sideCursor = max(m.sideCursor-1, 0)
+	case "down", "j":
+		m.sideCursor = min(m.sideCursor+1, len(m.sidebar)-1)
+	case "enter", " ":
+		m.scope = m.sidebar[m.sideCursor]
+		m.cursor, m.previewScroll = 0, 0
+		m.applyFilters()
+		m.focus = focusList
+	case "tab", "esc":
+		m.focus = focusList
+	}
+	return nil
+}
+
+func (m *model) startInput(kind inputKind, text string) {
+	m.input, m.buffer = kind, []rune(text)
+	m.status = ""
+}
+
+// handleInputKey edits the text being typed. The filter and the full-text
+// search follow every key; Esc clears them.
+func (m *model) handleInputKey(msg tea.KeyMsg) tea.Cmd {
+	if m.input == inputConfirmDelete {
+		m.input = inputNone
+		if msg.String() == "y" {
+			note := m.selected()
+			if err := m.service.DeleteNote(note); err != nil {
+				m.setError(err)
+			} else {
+				m.setStatus("Deleted %s (%s)", note.Title, note.ID)
+				m.removeNote(note.ID)
+			}
+		}
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		input := m.input
+		m.input, m.buffer = inputNone, nil
+		if input == inputFilter || input == inputSearch {
+			m.updateQuery(input, "")
+		}
+		return nil
+	case "enter":
+		input, text := m.input, strings.TrimSpace(string(m.buffer))
+		m.input, m.buffer = inputNone, nil
+		return m.submitInput(input, text)
+	case "backspace":
+		if len(m.buffer) > 0 {
+			m.buffer = m.buffer[:len(m.buffer)-1]
+		}
+	case "ctrl+u":
+		m.buffer = nil
+	case "ctrl+w":
+		text := strings.TrimRightFunc(string(m.buffer), unicode.IsSpace)
+		m.buffer = []rune(text[:strings.LastIndexFunc(text, unicode.IsSpace)+1])
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.buffer = append(m.buffer, msg.Runes...)
+		}
+	}
+
+	if m.input == inputFilter || m.input == inputSearch {
+		m.updateQuery(m.input, string(m.buffer))
+	}
+	return nil
+}
+
+func (m *model) updateQuery(input inputKind, text string) {
+	m.previewScroll = 0
+	if input == inputFilter {
+		m.filter = text
+		m.cursor = 0
+		m.applyFilters()
+		return
+	}
+	m.search = text
+	m.runSearch()
+}
+
+func (m *model) submitInput(input inputKind, text string) tea.Cmd {
+	switch input {
+	case inputRetag:
+		note := m.selected()
+		if note == nil {
+			return nil
+		}
+		var tags []string
+		for _, tag := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			tags = append(tags, strings.TrimPrefix(tag, "#"))
+		}
+		updated, err := m.service.SetTags(note, tags)
+		if err != nil {
+			m.setError(err)
+			return nil
+		}
+		if err := m.reload(); err != nil {
+			m.setError(err)
+			return nil
+		}
+		m.selectID(updated.ID)
+		m.setStatus("Tagged %s: %s", updated.Title, strings.Join(updated.Tags, ", "))
+
+	case inputCreate:
+		if text == "" {
+			return nil
+		}
+		opts := models.CreateOptions{Title: text}
+		switch m.scope.kind {
+		case "mode":
+			opts.Mode = m.scope.value
+		case "tag":
+			opts.Tags = []string{m.scope.value}
+		}
+		return m.runEditor(func() (string, error) {
+			note, err := m.service.CreateNote(opts)
+			if err != nil {
+				return "", err
+			}
+			return note.ID, nil
+		})
+	}
+	return nil
+}
+
+// removeNote drops a deleted note without reading the index again
+func (m *model) removeNote(id string) {
+	notes := make([]*models.Note, 0, len(m.notes))
+	for _, note := range m.notes {
+		if note.ID != id {
+			notes = append(notes, note)
+		}
+	}
+	m.setNotes(notes)
+}
+
+// frontmatterTags reads the tags retagging can change from a note's file,
+// leaving out the inline #tags of its body
+func frontmatterTags(note *models.Note) []string {
+	content, err := readNote(note)
+	if err != nil {
+		return note.Tags
+	}
+
+	fields, _ := service.SplitNote(content)
+	var tags []string
+	for _, tag := range strings.Split(strings.Trim(fields["tags"], "[] "), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// readNote reads a note's file for the preview
+func readNote(note *models.Note) (string, error) {
+	content, err := os.ReadFile(note.FilePath)
+	return string(content), err
+}
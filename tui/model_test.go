@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sk25469/jot/models"
+)
+
+func testNotes() []*models.Note {
+	return []*models.Note{
+		{ID: "a1", Title: "Daily reflection", Mode: "journal", Tags: []string{"review"}},
+		{ID: "b2", Title: "Kafka offsets", Mode: "dev", Tags: []string{"kafka"}},
+		{ID: "c3", Title: "Offset reset broke", Mode: "dev", Tags: []string{"kafka", "incident"}},
+	}
+}
+
+func visibleIDs(m *model) []string {
+	var ids []string
+	for _, note := range m.visible {
+		ids = append(ids, note.ID)
+	}
+	return ids
+}
+
+func typeKeys(m tea.Model, keys ...string) {
+	for _, key := range keys {
+		m.Update(keyMsg(key))
+	}
+}
+
+// keyMsg returns the message for a named key, or for typing text
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "ctrl+n":
+		return tea.KeyMsg{Type: tea.KeyCtrlN}
+	case "ctrl+u":
+		return tea.KeyMsg{Type: tea.KeyCtrlU}
+	case "ctrl+w":
+		return tea.KeyMsg{Type: tea.KeyCtrlW}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestBuildSidebar(t *testing.T) {
+	items := buildSidebar(testNotes())
+
+	expected := []sidebarItem{
+		{count: 3},
+		{kind: "mode", value: "dev", count: 2},
+		{kind: "mode", value: "journal", count: 1},
+		{kind: "tag", value: "kafka", count: 2},
+		{kind: "tag", value: "incident", count: 1},
+		{kind: "tag", value: "review", count: 1},
+	}
+	if !reflect.DeepEqual(items, expected) {
+		t.Errorf("buildSidebar() = %+v, expected %+v", items, expected)
+	}
+}
+
+func TestModelFilters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     []string
+		expected []string
+	}{
+		{"all notes", nil, []string{"a1", "b2", "c3"}},
+		{"fuzzy filter", []string{"/", "o", "f", "f"}, []string{"b2", "c3"}},
+		{"filter is kept after enter", []string{"/", "d", "a", "i", "enter"}, []string{"a1"}},
+		{"esc in the prompt clears the filter", []string{"/", "k", "a", "f", "esc"}, []string{"a1", "b2", "c3"}},
+		{"backspace widens the filter", []string{"/", "r", "e", "f", "backspace", "backspace"}, []string{"a1", "c3"}},
+		{"sidebar mode", []string{"tab", "down", "enter"}, []string{"b2", "c3"}},
+		{"sidebar tag and filter", []string{"tab", "down", "down", "down", "down", "enter", "/", "c"}, []string{"c3"}},
+		{"esc clears the sidebar entry", []string{"tab", "down", "enter", "esc"}, []string{"a1", "b2", "c3"}},
+	}
+
+	for _, tc := range testCases {
+		m := newModel(nil)
+		m.width = 120
+		m.setNotes(testNotes())
+		typeKeys(m, tc.keys...)
+
+		if ids := visibleIDs(m); !reflect.DeepEqual(ids, tc.expected) {
+			t.Errorf("%s: visible notes = %v, expected %v", tc.name, ids, tc.expected)
+		}
+	}
+}
+
+func TestModelKeepsCursorOnReload(t *testing.T) {
+	m := newModel(nil)
+	m.setNotes(testNotes())
+	typeKeys(m, "j", "j")
+	if note := m.selected(); note == nil || note.ID != "c3" {
+		t.Fatalf("selected() = %v, expected c3", note)
+	}
+
+	// A new note at the top keeps the cursor on the same note
+	notes := append([]*models.Note{{ID: "d4", Title: "New", Mode: "dev"}}, testNotes()...)
+	m.setNotes(notes)
+	if note := m.selected(); note == nil || note.ID != "c3" {
+		t.Errorf("selected() after reload = %v, expected c3", note)
+	}
+}
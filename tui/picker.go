@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
+)
+
+// Pick runs the fuzzy note picker full-screen over some notes and returns
+// the one chosen, or nil when cancelled
+func Pick(notes []*models.Note) (*models.Note, error) {
+	final, err := tea.NewProgram(newPicker(notes), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+	return final.(*picker).chosen, nil
+}
+
+// picker is the state of the fuzzy note picker: the query typed so far and
+// the notes matching it, with a cursor into them
+type picker struct {
+	notes   []*models.Note
+	query   []rune
+	matches []*models.Note
+	cursor  int
+	offset  int // First match shown in the list
+	chosen  *models.Note
+
+	preview       preview
+	width, height int
+}
+
+func newPicker(notes []*models.Note) *picker {
+	return &picker{notes: notes, matches: notes, width: 80, height: 24}
+}
+
+func (p *picker) selected() *models.Note {
+	if p.cursor < len(p.matches) {
+		return p.matches[p.cursor]
+	}
+	return nil
+}
+
+func (p *picker) setQuery(query []rune) {
+	p.query = query
+	p.matches = service.FilterNotes(string(query), p.notes)
+	p.cursor, p.offset = 0, 0
+}
+
+func (p *picker) move(delta int) {
+	p.cursor = max(0, min(p.cursor+delta, len(p.matches)-1))
+}
+
+func (p *picker) Init() tea.Cmd {
+	return nil
+}
+
+// Update applies typed or pasted text, editing keys, arrows and Enter or Esc
+func (p *picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+		return p, nil
+
+	case tea.KeyMsg:
+		page := max(p.height-3, 1)
+
+		switch msg.String() {
+		case "esc", "ctrl+c", "ctrl+g":
+			return p, tea.Quit
+		case "enter":
+			if note := p.selected(); note != nil {
+				p.chosen = note
+				return p, tea.Quit
+			}
+		case "up", "ctrl+p", "ctrl+k":
+			p.move(-1)
+		case "down", "ctrl+n", "ctrl+j":
+			p.move(1)
+		case "pgup":
+			p.move(-page)
+		case "pgdown":
+			p.move(page)
+		case "backspace":
+			if len(p.query) > 0 {
+				p.setQuery(p.query[:len(p.query)-1])
+			}
+		case "ctrl+u":
+			p.setQuery(nil)
+		case "ctrl+w":
+			query := strings.TrimRightFunc(string(p.query), unicode.IsSpace)
+			p.setQuery([]rune(query[:strings.LastIndexFunc(query, unicode.IsSpace)+1]))
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				p.setQuery(append(p.query, msg.Runes...))
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// View draws the prompt, the list of matches and a preview of the note under
+// the cursor, beside the list on wide terminals and below it on narrow ones
+func (p *picker) View() string {
+	rows := max(p.height-2, 1)
+	listWidth, listRows := p.width, rows
+	previewWidth, previewRows := 0, 0
+	if p.width >= 80 {
+		listWidth = p.width * 45 / 100
+		previewWidth, previewRows = p.width-listWidth-3, rows
+	} else if rows >= 8 {
+		listRows = rows / 2
+		previewWidth, previewRows = p.width, rows-listRows-1
+	}
+
+	p.offset = scrollOffset(p.cursor, p.offset, listRows)
+	list := make([]string, 0, listRows)
+	for i := p.offset; i < len(p.matches) && len(list) < listRows; i++ {
+		list = append(list, renderNoteLine(p.matches[i], i == p.cursor, i == p.cursor, listWidth))
+	}
+	list = window(list, 0, listRows)
+	preview := p.renderPreview(previewWidth, previewRows)
+
+	count := subtleStyle.Render(fmt.Sprintf("%d/%d", len(p.matches), len(p.notes)))
+	prompt := accentStyle.Render(">") + " " + string(p.query) + lipgloss.NewStyle().Reverse(true).Render(" ")
+	gap := max(p.width-ansi.StringWidth(prompt)-ansi.StringWidth(count), 1)
+	rule := borderStyle.Render(strings.Repeat("─", p.width))
+
+	lines := []string{prompt + strings.Repeat(" ", gap) + count, rule}
+	if p.width >= 80 {
+		separator := borderStyle.Render(" │ ")
+		for i := 0; i < rows; i++ {
+			lines = append(lines, fitLine(list[i], listWidth)+separator+fitLine(preview[i], previewWidth))
+		}
+	} else {
+		lines = append(lines, list...)
+		if previewRows > 0 {
+			lines = append(lines, rule)
+			lines = append(lines, preview...)
+		}
+	}
+
+	return strings.Join(lines[:min(len(lines), p.height)], "\n")
+}
+
+// renderPreview renders the note under the cursor in exactly rows lines
+func (p *picker) renderPreview(width, rows int) []string {
+	note := p.selected()
+	if note == nil || rows == 0 || width <= 0 {
+		return window(nil, 0, rows)
+	}
+
+	if p.preview.id != note.ID || !p.preview.updated.Equal(note.UpdatedAt) || p.preview.width != width {
+		p.preview = preview{id: note.ID, updated: note.UpdatedAt, width: width, lines: renderNotePreview(note, width)}
+	}
+	return window(p.preview.lines, 0, rows)
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPickerKeys(t *testing.T) {
+	testCases := []struct {
+		name       string
+		keys       []string
+		quit       bool
+		query      string
+		selectedID string
+		chosenID   string
+	}{
+		{"enter picks the first note", []string{"enter"}, true, "", "a1", "a1"},
+		{"typing filters", []string{"reset"}, false, "reset", "c3", ""},
+		{"arrows move", []string{"down", "down", "up"}, false, "", "b2", ""},
+		{"moving stops at the ends", []string{"up", "ctrl+n", "ctrl+n", "ctrl+n", "ctrl+n"}, false, "", "c3", ""},
+		{"backspace edits the query", []string{"offx", "backspace"}, false, "off", "b2", ""},
+		{"ctrl-w deletes a word", []string{"kafka", " ", "off", "ctrl+w"}, false, "kafka ", "b2", ""},
+		{"ctrl-u clears", []string{"zzz", "ctrl+u"}, false, "", "a1", ""},
+		{"esc cancels", []string{"esc"}, true, "", "a1", ""},
+		{"enter with no match does nothing", []string{"zzz", "enter"}, false, "zzz", "", ""},
+	}
+
+	for _, tc := range testCases {
+		p := newPicker(testNotes())
+		quit := false
+		for _, key := range tc.keys {
+			if _, cmd := p.Update(keyMsg(key)); cmd != nil {
+				_, quit = cmd().(tea.QuitMsg)
+			}
+		}
+
+		selectedID, chosenID := "", ""
+		if note := p.selected(); note != nil {
+			selectedID = note.ID
+		}
+		if p.chosen != nil {
+			chosenID = p.chosen.ID
+		}
+		if quit != tc.quit || string(p.query) != tc.query || selectedID != tc.selectedID || chosenID != tc.chosenID {
+			t.Errorf("%s: got quit %t, query %q, selected %q, chosen %q; expected %t, %q, %q, %q",
+				tc.name, quit, string(p.query), selectedID, chosenID, tc.quit, tc.query, tc.selectedID, tc.chosenID)
+		}
+	}
+}
+
+func TestPickerResize(t *testing.T) {
+	p := newPicker(testNotes())
+	for _, size := range []tea.WindowSizeMsg{{Width: 100, Height: 20}, {Width: 50, Height: 12}} {
+		p.Update(size)
+
+		lines := strings.Split(p.View(), "\n")
+		if len(lines) != size.Height {
+			t.Errorf("View() at %dx%d has %d lines, expected %d", size.Width, size.Height, len(lines), size.Height)
+		}
+		for i, line := range lines {
+			if width := ansi.StringWidth(line); width > size.Width {
+				t.Errorf("View() at %dx%d line %d is %d cells wide", size.Width, size.Height, i, width)
+			}
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sk25469/jot/models"
+	"github.com/sk25469/jot/service"
+	"github.com/sk25469/jot/styles"
+)
+
+const sidebarWidth = 24
+
+var (
+	accentStyle  = lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	subtleStyle  = lipgloss.NewStyle().Foreground(styles.Subtle)
+	borderStyle  = lipgloss.NewStyle().Foreground(styles.Muted)
+	sectionStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	titleBar     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#282A36")).Background(styles.Primary).Padding(0, 1)
+	errorText    = lipgloss.NewStyle().Foreground(styles.Error)
+	successText  = lipgloss.NewStyle().Foreground(styles.Success)
+)
+
+var helpText = "enter edit · n new · t tag · d delete · / filter · ? search · tab sidebar · ^d/^u scroll · q quit"
+
+func (m *model) showSidebar() bool {
+	return m.width >= 90
+}
+
+func (m *model) showPreview() bool {
+	return m.width >= 60
+}
+
+// bodyHeight is the number of rows between the title bar and the status line
+func (m *model) bodyHeight() int {
+	return max(m.height-2, 1)
+}
+
+func (m *model) View() string {
+	rows := m.bodyHeight()
+	width := m.width
+
+	var columns [][]string
+	var widths []int
+	if m.showSidebar() {
+		columns = append(columns, m.renderSidebar(rows))
+		widths = append(widths, sidebarWidth)
+		width -= sidebarWidth + 3
+	}
+
+	listWidth := width
+	if m.showPreview() {
+		listWidth = width * 45 / 100
+	}
+	columns = append(columns, m.renderList(listWidth, rows))
+	widths = append(widths, listWidth)
+
+	if m.showPreview() {
+		previewWidth := width - listWidth - 3
+		columns = append(columns, m.renderPreview(previewWidth, rows))
+		widths = append(widths, previewWidth)
+	}
+
+	lines := []string{m.renderTitleBar()}
+	separator := borderStyle.Render(" │ ")
+	for row := 0; row < rows; row++ {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = fitLine(column[row], widths[i])
+		}
+		lines = append(lines, strings.Join(cells, separator))
+	}
+	lines = append(lines, m.renderStatusLine())
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *model) renderTitleBar() string {
+	parts := []string{titleBar.Render("jot")}
+	if m.scope.kind != "" {
+		parts = append(parts, subtleStyle.Render(m.scope.kind+":")+m.scope.value)
+	}
+	if m.search != "" {
+		parts = append(parts, subtleStyle.Render("search:")+m.search)
+	}
+	if m.filter != "" {
+		parts = append(parts, subtleStyle.Render("filter:")+m.filter)
+	}
+
+	left := strings.Join(parts, "  ")
+	count := subtleStyle.Render(fmt.Sprintf("%d/%d notes", len(m.visible), len(m.notes)))
+	gap := max(m.width-ansi.StringWidth(left)-ansi.StringWidth(count), 1)
+	return left + strings.Repeat(" ", gap) + count
+}
+
+func (m *model) renderStatusLine() string {
+	prompt := ""
+	switch m.input {
+	case inputFilter:
+		prompt = "/"
+	case inputSearch:
+		prompt = "?"
+	case inputRetag:
+		prompt = "tags: "
+	case inputCreate:
+		prompt = "new note title: "
+	case inputConfirmDelete:
+		note := m.selected()
+		return fitLine(errorText.Render(fmt.Sprintf("Delete %s (%s)? [y/N]", note.Title, note.ID)), m.width)
+	}
+
+	if m.input != inputNone {
+		cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
+		return fitLine(accentStyle.Render(prompt)+string(m.buffer)+cursor, m.width)
+	}
+
+	switch {
+	case m.status != "" && m.statusIsError:
+		return fitLine(errorText.Render(m.status), m.width)
+	case m.status != "":
+		return fitLine(successText.Render(m.status), m.width)
+	}
+	return fitLine(subtleStyle.Render(helpText), m.width)
+}
+
+func (m *model) renderSidebar(rows int) []string {
+	lines := make([]string, 0, rows)
+	kind := "-"
+	for i, item := range m.sidebar {
+		if item.kind != kind {
+			switch item.kind {
+			case "mode":
+				lines = append(lines, "", sectionStyle.Render("Modes"))
+			case "tag":
+				lines = append(lines, "", sectionStyle.Render("Tags"))
+			}
+			kind = item.kind
+		}
+
+		label := item.value
+		switch item.kind {
+		case "":
+			label = "All notes"
+		case "tag":
+			label = "#" + item.value
+		}
+
+		marker := "  "
+		if item == m.scope || (item.kind == "" && m.scope.kind == "") {
+			marker = accentStyle.Render("● ")
+		}
+
+		count := fmt.Sprint(item.count)
+		label = styles.Truncate(label, max(sidebarWidth-len(count)-3, 1))
+		text := label + strings.Repeat(" ", max(sidebarWidth-2-ansi.StringWidth(label)-len(count), 1)) + count
+
+		switch {
+		case m.focus == focusSidebar && i == m.sideCursor:
+			text = lipgloss.NewStyle().Reverse(true).Render(text)
+		case item.kind == "mode":
+			text = lipgloss.NewStyle().Foreground(styles.ModeColor(item.value)).Render(text)
+		default:
+			text = styles.ContentStyle.Render(text)
+		}
+		lines = append(lines, marker+text)
+	}
+
+	// Scroll the sidebar so its cursor stays in view
+	offset := 0
+	if m.focus == focusSidebar {
+		if line := m.sidebarLine(); line >= rows {
+			offset = line - rows + 1
+		}
+	}
+	return window(lines, offset, rows)
+}
+
+// sidebarLine is the line of the sidebar the cursor is on, counting the
+// section headings above it
+func (m *model) sidebarLine() int {
+	line, kind := 0, "-"
+	for i, item := range m.sidebar {
+		if item.kind != kind && item.kind != "" {
+			line += 2
+		}
+		kind = item.kind
+		if i == m.sideCursor {
+			return line
+		}
+		line++
+	}
+	return line
+}
+
+func (m *model) renderList(width, rows int) []string {
+	if len(m.visible) == 0 {
+		return window([]string{subtleStyle.Render("No notes found.")}, 0, rows)
+	}
+
+	m.offset = scrollOffset(m.cursor, m.offset, rows)
+	lines := make([]string, 0, rows)
+	for i := m.offset; i < len(m.visible) && len(lines) < rows; i++ {
+		lines = append(lines, m.renderNote(m.visible[i], i == m.cursor, width))
+	}
+	return window(lines, 0, rows)
+}
+
+// renderNote renders one line of the list: the ID, mode and title
+func (m *model) renderNote(note *models.Note, current bool, width int) string {
+	return renderNoteLine(note, current, current && m.focus == focusList, width)
+}
+
+// renderNoteLine renders a note as a list line, marked when the cursor is on
+// it and with its title highlighted when the list has the focus
+func renderNoteLine(note *models.Note, current, highlight bool, width int) string {
+	marker := "  "
+	titleStyle := styles.ContentStyle
+	if current {
+		marker = accentStyle.Render("▌ ")
+	}
+	if highlight {
+		titleStyle = accentStyle
+	}
+
+	mode := lipgloss.NewStyle().Foreground(styles.ModeColor(note.Mode)).Render(note.Mode)
+	prefix := marker + styles.IDStyle.Render(note.ID) + " " + mode + " "
+	title := styles.Truncate(note.Title, max(width-ansi.StringWidth(prefix), 1))
+	return prefix + titleStyle.Render(title)
+}
+
+// renderPreview renders the note under the cursor, scrolled by the preview
+// offset
+func (m *model) renderPreview(width, rows int) []string {
+	note := m.selected()
+	if note == nil || width <= 0 {
+		return window(nil, 0, rows)
+	}
+
+	if m.preview.id != note.ID || !m.preview.updated.Equal(note.UpdatedAt) || m.preview.width != width {
+		m.preview = preview{id: note.ID, updated: note.UpdatedAt, width: width, lines: renderNotePreview(note, width)}
+	}
+
+	m.previewScroll = max(0, min(m.previewScroll, len(m.preview.lines)-rows))
+	return window(m.preview.lines, m.previewScroll, rows)
+}
+
+// renderNotePreview renders a note's header and Markdown body in lines
+func renderNotePreview(note *models.Note, width int) []string {
+	lines := []string{
+		accentStyle.Render(note.Title),
+		subtleStyle.Render(fmt.Sprintf("%s · %s · %s", note.ID, note.Mode, note.CreatedAt.Format("2006-01-02 15:04"))),
+	}
+	if len(note.Tags) > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.Secondary).Render("#"+strings.Join(note.Tags, " #")))
+	}
+	lines = append(lines, borderStyle.Render(strings.Repeat("─", width)))
+
+	content, err := readNote(note)
+	if err != nil {
+		return append(lines, errorText.Render(err.Error()))
+	}
+	_, body := service.SplitNote(content)
+	return append(lines, strings.Split(styles.RenderMarkdown(body, width), "\n")...)
+}
+
+// scrollOffset returns the first line of a list rows tall to show so that
+// the cursor stays in view, moving the current offset as little as possible
+func scrollOffset(cursor, offset, rows int) int {
+	if cursor < offset {
+		return cursor
+	}
+	if cursor >= offset+rows {
+		return cursor - rows + 1
+	}
+	return offset
+}
+
+// window returns exactly rows lines starting at offset, padding with empty
+// lines
+func window(lines []string, offset, rows int) []string {
+	out := make([]string, rows)
+	for i := range out {
+		if offset+i < len(lines) {
+			out[i] = lines[offset+i]
+		}
+	}
+	return out
+}
+
+// fitLine truncates or pads styled text to exactly width cells
+func fitLine(s string, width int) string {
+	if ansi.StringWidth(s) > width {
+		s = ansi.Truncate(s, width, "…")
+	}
+	return s + strings.Repeat(" ", max(width-ansi.StringWidth(s), 0))
+}